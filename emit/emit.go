@@ -0,0 +1,80 @@
+// Package emit runs emitters and writes the files they generate.
+//
+// An [Emitter] produces files through a [FileWriter], the built-in one generates gmsk, see [github.com/fardream/gen-gmsk/gen.GmskEmitter].
+// Emitters are created with what they generate from: the built-in ones with the header and the config normalized by
+// [github.com/fardream/gen-gmsk/gen.Normalize], and third party ones, for example of documentation or internal wrappers,
+// usually with the [github.com/fardream/gen-gmsk/model.API] returned by it. They are run by [Run] with the built-in one.
+package emit
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"mvdan.cc/gofumpt/format"
+)
+
+// FileWriter writes the emitted files, name is relative to the output directory.
+type FileWriter interface {
+	WriteFile(name string, content []byte) error
+}
+
+// Emitter generates files from what it is created with.
+type Emitter interface {
+	// Name identifies the emitter in errors.
+	Name() string
+	// Emit writes the files.
+	Emit(w FileWriter) error
+}
+
+// Run runs the emitters in order, stopping at the first error.
+func Run(w FileWriter, emitters ...Emitter) error {
+	for _, e := range emitters {
+		if err := e.Emit(w); err != nil {
+			return fmt.Errorf("emitter %s: %w", e.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// DirWriter writes the files into Dir, creating the sub directories, or to stdout if Dir is empty.
+// Go files are formatted with gofumpt, and written unformatted if they cannot be formatted.
+type DirWriter struct {
+	Dir        string
+	ModulePath string // module of the generated go files, for gofumpt
+}
+
+func (d *DirWriter) WriteFile(name string, content []byte) error {
+	var formatErr error
+	if strings.HasSuffix(name, ".go") {
+		formatted, err := format.Source(content, format.Options{
+			LangVersion: "go1.21",
+			ExtraRules:  true,
+			ModulePath:  d.ModulePath,
+		})
+		if err == nil {
+			content = formatted
+		} else {
+			formatErr = fmt.Errorf("failed to format %s: %w", name, err)
+		}
+	}
+
+	if d.Dir == "" {
+		if _, err := os.Stdout.Write(content); err != nil {
+			return err
+		}
+		return formatErr
+	}
+
+	fullPath := path.Join(d.Dir, name)
+	if err := os.MkdirAll(path.Dir(fullPath), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(fullPath, content, 0o644); err != nil {
+		return err
+	}
+
+	return formatErr
+}
@@ -0,0 +1,76 @@
+package emit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testEmitter writes its files, or fails with err.
+type testEmitter struct {
+	name  string
+	files map[string]string
+	err   error
+}
+
+func (e *testEmitter) Name() string { return e.name }
+
+func (e *testEmitter) Emit(w FileWriter) error {
+	if e.err != nil {
+		return e.err
+	}
+	for name, content := range e.files {
+		if err := w.WriteFile(name, []byte(content)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestDirWriter(t *testing.T) {
+	dir := t.TempDir()
+	w := &DirWriter{Dir: dir, ModulePath: "example.com/gmsk"}
+
+	if err := w.WriteFile("sub/a.go", []byte("package gmsk\nfunc  A( ) {}\n")); err != nil {
+		t.Fatal(err)
+	}
+	if content, err := os.ReadFile(filepath.Join(dir, "sub", "a.go")); err != nil || string(content) != "package gmsk\n\nfunc A() {}\n" {
+		t.Errorf("a.go is not formatted: %q %v", content, err)
+	}
+
+	notFormatted := "not  go\n"
+	if err := w.WriteFile("b.go", []byte(notFormatted)); err == nil || !strings.Contains(err.Error(), "failed to format b.go") {
+		t.Errorf("expected format error, got %v", err)
+	}
+	if content, err := os.ReadFile(filepath.Join(dir, "b.go")); err != nil || string(content) != notFormatted {
+		t.Errorf("b.go is not written unformatted: %q %v", content, err)
+	}
+
+	if err := w.WriteFile("c.md", []byte("#  title\n")); err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := os.ReadFile(filepath.Join(dir, "c.md")); string(content) != "#  title\n" {
+		t.Errorf("c.md is changed to %q", content)
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	failure := errors.New("failure")
+	err := Run(&DirWriter{Dir: dir},
+		&testEmitter{name: "first", files: map[string]string{"first.txt": "first"}},
+		&testEmitter{name: "second", err: failure},
+		&testEmitter{name: "third", files: map[string]string{"third.txt": "third"}},
+	)
+	if !errors.Is(err, failure) || !strings.HasPrefix(err.Error(), "emitter second: ") {
+		t.Errorf("unexpected error %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "first.txt")); err != nil {
+		t.Errorf("first emitter is not run: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "third.txt")); err == nil {
+		t.Error("emitter after the failure is run")
+	}
+}
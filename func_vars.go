@@ -1,45 +0,0 @@
-package main
-
-var funcVars []pair = []pair{
-	// c
-	{"cj", "Cj"},
-	{"cfix", "CFix"},
-	// bar
-	{"barcj", "BarCj"},
-	{"barc", "BarC"},
-	{"baraij", "BarAij"},
-	{"bara", "BarA"},
-	// acc
-	{"accgvector", "AccGVector"},
-	{"accftrip", "AccFTrip"},
-	// afe
-	{"afef", "AfeF"},
-	{"afeg", "AfeG"},
-	{"afebarf", "AfeBarF"},
-	// qoqc
-	{"qobj", "QObj"},
-	{"qconk", "QConK"},
-	// var
-	{"vartype", "VarType"},
-	// a
-	{"acol", "ACol"},
-	{"arow", "ARow"},
-	{"apiece", "APiece"},
-	//
-	{"solsta", "SolSta"},
-	{"prosta", "ProSta"},
-
-	{"sparse", "Sparse"},
-
-	// params
-	{"intparam", "IntParam"},
-	{"douparam", "DouParam"},
-	{"strparam", "StrParam"},
-	// info
-	{"intinf", "IntInf"},
-	{"douinf", "DouInf"},
-	{"strinf", "StrInf"},
-	//
-	{"primal", "Primal"},
-	{"dual", "Dual"},
-}
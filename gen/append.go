@@ -0,0 +1,176 @@
+package gen
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/fardream/gen-gmsk/mskh"
+)
+
+// appendConfig configures the Append variant of a getter, which fills an array appended to a caller provided slice.
+type appendConfig struct {
+	Param    string   `json:"param"`     // C name of the array parameter
+	Length   string   `json:"length"`    // go expression of the length, in go names of the other parameters
+	SizeFunc string   `json:"size_func"` // C function returning the length, used when length is empty
+	SizeArgs []string `json:"size_args"` // go names of the parameters passed to size_func
+	Disable  bool     `json:"disable"`   // do not generate the Append variant
+}
+
+// appendLocals are the local variables of the Append variants.
+var appendLocals = []string{"dst", "appendLen", "err"}
+
+// inferAppend finds the Append variant of list and slice getters with a single array parameter,
+// whose length is last - first or num.
+func inferAppend(fc *FuncConfig) *appendConfig {
+	if fc.FuncType != funcType_TASK_GETLIST_OR_SLICE {
+		return nil
+	}
+	var array *ParamConfig
+	names := make(map[string]struct{})
+	for _, pc := range fc.params {
+		names[pc.CName] = struct{}{}
+		if pc.IsTask || !pc.IsPointer || pc.IsConst {
+			continue
+		}
+		if array != nil {
+			return nil
+		}
+		array = pc
+	}
+	if array == nil {
+		return nil
+	}
+
+	r := &appendConfig{Param: array.CName}
+	_, hasFirst := names["first"]
+	_, hasLast := names["last"]
+	_, hasNum := names["num"]
+	switch {
+	case array.IsOutputSlice():
+		r.Length = array.Length
+	case hasFirst && hasLast:
+		r.Length = "last - first"
+	case hasNum:
+		r.Length = "num"
+	default:
+		return nil
+	}
+
+	return r
+}
+
+// normalizeAppend checks the Append config of the function, or infers it.
+// Functions with other outputs or strings are not supported.
+func normalizeAppend(f *mskh.MskFunction, fc *FuncConfig) {
+	explicit := fc.Append != nil
+	if !explicit {
+		fc.Append = inferAppend(fc)
+	}
+	// functions with only names and receivers, like the ones from the rust binding for the name table, have nothing to check.
+	onlyReceiver := len(f.Parameters) == 1 && (fc.IsTask() || fc.IsEnv())
+	if fc.Append == nil || fc.Append.Disable || len(f.Parameters) == 0 || onlyReceiver {
+		fc.appendParam = nil
+		return
+	}
+
+	fail := func(format string, args ...any) {
+		if explicit {
+			log.Panicf("append of %s: %s", f.Name, fmt.Sprintf(format, args...))
+		}
+		fc.appendParam = nil
+	}
+
+	if !fc.IsTask() {
+		fail("only task functions are supported")
+		return
+	}
+	if fc.Append.Length == "" && fc.Append.SizeFunc == "" {
+		fail("either length or size_func is required")
+		return
+	}
+	idx := slices.IndexFunc(fc.params, func(pc *ParamConfig) bool { return pc.CName == fc.Append.Param })
+	if idx < 0 {
+		fail("parameter %s is not found", fc.Append.Param)
+		return
+	}
+	array := fc.params[idx]
+	if !array.IsPointer || array.IsConst || array.IsStrOut || array.IsBoolOut || array.OrigCType == "char *" {
+		fail("parameter %s is not an array to fill", array.CName)
+		return
+	}
+	if array.IsOutput && !array.IsOutputSlice() {
+		fail("parameter %s is a single output value", array.CName)
+		return
+	}
+	for _, pc := range fc.params {
+		if pc == array {
+			continue
+		}
+		if pc.IsOutput || pc.OrigCType == "const char *" || pc.OrigCType == "char *" || (pc.IsPointer && pc.Nullable) {
+			fail("parameter %s needs preparation, which is not supported", pc.CName)
+			return
+		}
+		if slices.Contains(appendLocals, pc.Name) {
+			fail("parameter %s is named as a local variable", pc.CName)
+			return
+		}
+	}
+
+	fc.appendParam = array
+}
+
+// AppendParam is the array filled by the Append variant, nil if there is none.
+func (t *FuncTmplInput) AppendParam() *ParamConfig {
+	return t.appendParam
+}
+
+// AppendGoName is the name of the Append variant, Get is replaced by Append.
+func (t *FuncTmplInput) AppendGoName() string {
+	return "Append" + strings.TrimPrefix(t.GoName, "Get")
+}
+
+// AppendGoParams are the parameters of the Append variant after dst.
+func (t *FuncTmplInput) AppendGoParams() []string {
+	var r []string
+	for _, pc := range t.params {
+		if pc.IsTask || pc.IsEnv || pc.IsOutput || pc == t.appendParam {
+			continue
+		}
+		if pc.IsPointer {
+			r = append(r, fmt.Sprintf("%s []%s", pc.Name, pc.GoType))
+		} else {
+			r = append(r, fmt.Sprintf("%s %s", pc.Name, pc.GoType))
+		}
+	}
+
+	return r
+}
+
+// AppendSizeCall is the call to the size function, empty if the length is an expression.
+func (t *FuncTmplInput) AppendSizeCall() string {
+	if t.Append.Length != "" {
+		return ""
+	}
+	sfc, found := t.config.Funcs[t.Append.SizeFunc]
+	if !found || sfc.Skip || !sfc.IsTask() {
+		log.Panicf("size_func %s of %s is not a generated task method", t.Append.SizeFunc, t.CFunc.Name)
+	}
+
+	return fmt.Sprintf("task.%s(%s)", sfc.GoName, strings.Join(t.Append.SizeArgs, ", "))
+}
+
+// AppendCCallInputs are the inputs to the C function in the Append variant, where the array is a local slice.
+func (t *FuncTmplInput) AppendCCallInputs() []string {
+	var r []string
+	for i, pc := range t.params {
+		if pc == t.appendParam {
+			r = append(r, pc.ptrToFirst())
+		} else {
+			r = append(r, t.cCallInput(i, pc))
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,136 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+
+	"github.com/fardream/gen-gmsk/emit"
+	"github.com/fardream/gen-gmsk/mskh"
+)
+
+// cgoPlatform is the flags of a platform directory of the installation.
+type cgoPlatform struct {
+	Target     string // GOOS,GOARCH build constraint
+	IncludeDir string
+	LibDir     string
+	LibName    string
+	Rpath      bool // the library is found at runtime through rpath, not on windows
+}
+
+// newCgoPlatform is the flags of the platform directory, nil if the platform is unknown to go.
+func newCgoPlatform(install *mskh.Install, platform string, version mskh.MskVersion) *cgoPlatform {
+	target := mskh.PlatformGoTarget(platform)
+	if target == "" {
+		return nil
+	}
+	dir := filepath.ToSlash(install.PlatformDir(platform))
+	p := &cgoPlatform{
+		Target:     target,
+		IncludeDir: dir + "/h",
+		LibDir:     dir + "/bin",
+		LibName:    "mosek64",
+		Rpath:      true,
+	}
+	// the import library on windows has the version in its name, for example mosek64_11_2.lib.
+	if platform == "win64x86" {
+		p.LibName = fmt.Sprintf("mosek64_%d_%d", version.Major, version.Minor)
+		p.Rpath = false
+	}
+
+	return p
+}
+
+type cgoFlagsFileInput struct {
+	PackageName    string
+	DlopenBuildTag string // no linking to libmosek with this tag
+	Dir            string
+	Version        string
+	Platforms      []*cgoPlatform
+}
+
+// CgoFlagsEmitter is the [emit.Emitter] writing cgo_flags.go, which has the #cgo CFLAGS and LDFLAGS
+// of every platform directory in the installation.
+type CgoFlagsEmitter struct {
+	install *mskh.Install
+	version mskh.MskVersion
+	config  *OutputConfig
+}
+
+var _ emit.Emitter = (*CgoFlagsEmitter)(nil)
+
+// NewCgoFlagsEmitter creates the emitter of cgo_flags.go, version is from the header of the installation.
+func NewCgoFlagsEmitter(install *mskh.Install, version mskh.MskVersion, config *OutputConfig) *CgoFlagsEmitter {
+	return &CgoFlagsEmitter{install: install, version: version, config: config}
+}
+
+func (e *CgoFlagsEmitter) Name() string {
+	return "cgo-flags"
+}
+
+func (e *CgoFlagsEmitter) Emit(w emit.FileWriter) error {
+	input := &cgoFlagsFileInput{
+		PackageName:    e.config.PackageName,
+		DlopenBuildTag: e.config.DlopenBuildTag,
+		Dir:            filepath.ToSlash(e.install.Dir),
+		Version:        e.version.String(),
+	}
+	for _, platform := range e.install.Platforms() {
+		if p := newCgoPlatform(e.install, platform, e.version); p != nil {
+			input.Platforms = append(input.Platforms, p)
+		}
+	}
+	if len(input.Platforms) == 0 {
+		return fmt.Errorf("no platform supported by go is found in %s", e.install.Dir)
+	}
+
+	var content bytes.Buffer
+	if err := cgoFlagsFileTmpl.ExecuteTemplate(&content, "cgo-flags", input); err != nil {
+		return err
+	}
+
+	return w.WriteFile("cgo_flags.go", content.Bytes())
+}
+
+type pkgConfigFileInput struct {
+	Prefix  string
+	Version string
+	LibName string
+	Rpath   bool
+}
+
+// PkgConfigEmitter is the [emit.Emitter] writing mosek.pc of the platform of the installation, for #cgo pkg-config: mosek.
+type PkgConfigEmitter struct {
+	install *mskh.Install
+	version mskh.MskVersion
+}
+
+var _ emit.Emitter = (*PkgConfigEmitter)(nil)
+
+// NewPkgConfigEmitter creates the emitter of mosek.pc, version is from the header of the installation.
+func NewPkgConfigEmitter(install *mskh.Install, version mskh.MskVersion) *PkgConfigEmitter {
+	return &PkgConfigEmitter{install: install, version: version}
+}
+
+func (e *PkgConfigEmitter) Name() string {
+	return "pkg-config"
+}
+
+func (e *PkgConfigEmitter) Emit(w emit.FileWriter) error {
+	p := newCgoPlatform(e.install, e.install.Platform, e.version)
+	if p == nil {
+		return fmt.Errorf("platform %s is not supported by go", e.install.Platform)
+	}
+
+	var content bytes.Buffer
+	if err := cgoFlagsFileTmpl.ExecuteTemplate(&content, "pkg-config", &pkgConfigFileInput{
+		Prefix:  filepath.ToSlash(e.install.PlatformDir(e.install.Platform)),
+		Version: e.version.String(),
+		LibName: p.LibName,
+		Rpath:   p.Rpath,
+	}); err != nil {
+		return err
+	}
+
+	return w.WriteFile("mosek.pc", content.Bytes())
+}
@@ -0,0 +1,174 @@
+package gen
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fardream/gen-gmsk/mskh"
+)
+
+// goIdent is an identifier that will be declared in the generated go package.
+type goIdent struct {
+	Name     string // go name
+	Receiver string // empty for package level identifiers, otherwise Task or Env, or Params and SolverInfo for their fields
+	Kind     string // enum type, enum constant, function, method etc
+	Origin   string // the C declaration this identifier is generated from
+	Source   string // file:line of the C declaration, empty if unknown
+}
+
+func (i goIdent) String() string {
+	if i.Source != "" {
+		return fmt.Sprintf("%s %s from %s (%s)", i.Kind, i.Name, i.Origin, i.Source)
+	}
+	return fmt.Sprintf("%s %s from %s", i.Kind, i.Name, i.Origin)
+}
+
+// collision is a set of identifiers sharing the same name in the same scope.
+type collision struct {
+	Name     string
+	Receiver string
+	Idents   []goIdent
+}
+
+func (c *collision) String() string {
+	scope := "package scope"
+	if c.Receiver != "" {
+		scope = fmt.Sprintf("members of %s", c.Receiver)
+	}
+	var origins []string
+	for _, v := range c.Idents {
+		origins = append(origins, v.String())
+	}
+
+	return fmt.Sprintf("%s is declared %d times in %s: %s", c.Name, len(c.Idents), scope, strings.Join(origins, "; "))
+}
+
+// generatedIdents lists all the identifiers generated from the normalized header,
+// together with the reserved ones from the hand written part of the package.
+func generatedIdents(h *mskh.MosekH, config *OutputConfig) []goIdent {
+	var r []goIdent
+	for _, name := range config.ReservedNames {
+		r = append(r, goIdent{Name: name, Kind: "reserved name", Origin: "config"})
+	}
+
+	for _, enumName := range h.EnumList {
+		ec, found := config.Enums[enumName]
+		if !found || ec.Skip {
+			continue
+		}
+		var source string
+		e, found := h.Enums[enumName]
+		if found {
+			source = e.Source.String()
+		}
+		r = append(r, goIdent{Name: ec.GoName, Kind: "enum type", Origin: enumName, Source: source})
+		if !ec.IsEqualType {
+			r = append(r, goIdent{Name: fmt.Sprintf("_%s_map", ec.GoName), Kind: "enum map", Origin: enumName, Source: source})
+		}
+		if !found {
+			continue
+		}
+		for _, ev := range e.Values {
+			r = append(r, goIdent{Name: ec.ConstantGoName(ev.Name, enumConstantPrefix), Kind: "enum constant", Origin: ev.Name, Source: ev.Source.String()})
+		}
+	}
+
+	for _, f := range h.Functions {
+		fc, found := config.Funcs[f.Name]
+		if !found || fc.Skip {
+			continue
+		}
+		if fc.appendParam != nil {
+			r = append(r, goIdent{Name: (&FuncTmplInput{FuncConfig: fc}).AppendGoName(), Receiver: "Task", Kind: "append method", Origin: f.Name, Source: f.Source.String()})
+		}
+		switch {
+		case fc.IsEnv():
+			r = append(r, goIdent{Name: fc.GoName, Receiver: "Env", Kind: "method", Origin: f.Name, Source: f.Source.String()})
+		case fc.IsTask():
+			r = append(r, goIdent{Name: fc.GoName, Receiver: "Task", Kind: "method", Origin: f.Name, Source: f.Source.String()})
+		default:
+			r = append(r, goIdent{Name: fc.GoName, Kind: "function", Origin: f.Name, Source: f.Source.String()})
+		}
+	}
+
+	r = append(r,
+		goIdent{Name: "Params", Kind: "type", Origin: "params"},
+		goIdent{Name: "ApplyParams", Receiver: "Task", Kind: "method", Origin: "params"},
+		goIdent{Name: "ReadParams", Receiver: "Task", Kind: "method", Origin: "params"},
+	)
+	for _, p := range config.params {
+		r = append(r, goIdent{Name: p.MethodName, Receiver: "Params", Kind: "parameter field", Origin: p.CName})
+		if p.Kind == paramKind_INT && p.ValueEnum != "" {
+			r = append(r,
+				goIdent{Name: "Set" + p.MethodName, Receiver: "Task", Kind: "typed parameter setter", Origin: p.CName},
+				goIdent{Name: "Get" + p.MethodName, Receiver: "Task", Kind: "typed parameter getter", Origin: p.CName},
+			)
+		}
+	}
+
+	r = append(r,
+		goIdent{Name: "SolverInfo", Kind: "type", Origin: "solver info"},
+		goIdent{Name: "GetSolverInfo", Receiver: "Task", Kind: "method", Origin: "solver info"},
+	)
+	r = append(r,
+		goIdent{Name: "Solution", Kind: "type", Origin: "solution"},
+		goIdent{Name: "SolutionField", Kind: "type", Origin: "solution"},
+		goIdent{Name: "Solution", Receiver: "Task", Kind: "method", Origin: "solution"},
+		goIdent{Name: "SOLUTION_BARX", Kind: "solution field", Origin: "solution"},
+		goIdent{Name: "SOLUTION_BARS", Kind: "solution field", Origin: "solution"},
+		goIdent{Name: "SOLUTION_DOTY", Kind: "solution field", Origin: "solution"},
+		goIdent{Name: "SOLUTION_ALL", Kind: "solution field", Origin: "solution"},
+	)
+	r = append(r, goIdent{Name: "ErrNoMosek", Kind: "variable", Origin: "nocgo"})
+	r = append(r,
+		goIdent{Name: "HEADER_VERSION_MAJOR", Kind: "constant", Origin: "version"},
+		goIdent{Name: "HEADER_VERSION_MINOR", Kind: "constant", Origin: "version"},
+		goIdent{Name: "HEADER_VERSION_REVISION", Kind: "constant", Origin: "version"},
+		goIdent{Name: "CheckVersionInMakeEnv", Kind: "variable", Origin: "version"},
+		goIdent{Name: "CheckVersion", Kind: "function", Origin: "version"},
+		goIdent{Name: "checkVersionInMakeEnv", Kind: "function", Origin: "version"},
+	)
+	if config.DlopenBuildTag != "" {
+		r = append(r,
+			goIdent{Name: "Load", Kind: "function", Origin: "dlopen"},
+			goIdent{Name: "Loaded", Kind: "function", Origin: "dlopen"},
+			goIdent{Name: "RES_ERR_NOT_LOADED", Kind: "result code", Origin: "dlopen"},
+		)
+	}
+	for _, a := range solutionArrays {
+		r = append(r, goIdent{Name: a.Flag(), Kind: "solution field", Origin: "solution"})
+	}
+	for _, g := range buildInfoGroups(h, config) {
+		for _, item := range g.Items {
+			r = append(r, goIdent{Name: item.FieldName, Receiver: "SolverInfo", Kind: "information item field", Origin: item.CName})
+		}
+	}
+
+	return r
+}
+
+// findCollisions returns the identifiers that are declared more than once in the same scope.
+func findCollisions(h *mskh.MosekH, config *OutputConfig) []*collision {
+	type scopedName struct {
+		receiver string
+		name     string
+	}
+
+	byName := make(map[scopedName]*collision)
+	var r []*collision
+	for _, id := range generatedIdents(h, config) {
+		k := scopedName{receiver: id.Receiver, name: id.Name}
+		c, found := byName[k]
+		if !found {
+			c = &collision{Name: id.Name, Receiver: id.Receiver}
+			byName[k] = c
+			r = append(r, c)
+		}
+		c.Idents = append(c.Idents, id)
+	}
+
+	return slices.DeleteFunc(r, func(c *collision) bool {
+		return len(c.Idents) < 2
+	})
+}
@@ -1,10 +1,11 @@
-package main
+package gen
 
 import "strings"
 
 type CommonId struct {
 	GoName       string `json:"go_name"`
 	Skip         bool   `json:"skip"`
+	SkipReason   string `json:"skip_reason"` // why it is skipped, shown in the coverage report
 	Comment      string `json:"comment"`
 	IsDeprecated bool   `json:"is_deprecated"`
 	Url          string `json:"url"`
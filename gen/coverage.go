@@ -0,0 +1,256 @@
+package gen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fardream/gen-gmsk/emit"
+	"github.com/fardream/gen-gmsk/mskh"
+)
+
+// CoverageStatus is whether a C function is wrapped.
+type CoverageStatus string
+
+const (
+	Coverage_GENERATED   CoverageStatus = "generated"   // wrapped
+	Coverage_DEPRECATED  CoverageStatus = "deprecated"  // wrapped, but deprecated by mosek
+	Coverage_SKIPPED     CoverageStatus = "skipped"     // skipped by config
+	Coverage_UNSUPPORTED CoverageStatus = "unsupported" // skipped by config, and the generator cannot wrap it
+)
+
+var coverageStatuses = []CoverageStatus{Coverage_GENERATED, Coverage_DEPRECATED, Coverage_SKIPPED, Coverage_UNSUPPORTED}
+
+// CoverageEntry is the coverage of a C function.
+type CoverageEntry struct {
+	CName    string         `json:"c_name"`
+	Status   CoverageStatus `json:"status"`
+	Reason   string         `json:"reason,omitempty"` // skip_reason in config, or the detected cause
+	GoName   string         `json:"go_name,omitempty"`
+	FuncType string         `json:"func_type"`
+	File     string         `json:"file,omitempty"`
+}
+
+// CoverageTotal is the number of functions of each status.
+type CoverageTotal struct {
+	FuncType    string `json:"func_type"` // all for the totals of all the functions
+	Generated   int    `json:"generated"`
+	Deprecated  int    `json:"deprecated"`
+	Skipped     int    `json:"skipped"`
+	Unsupported int    `json:"unsupported"`
+	Total       int    `json:"total"`
+}
+
+func (t *CoverageTotal) add(status CoverageStatus) {
+	switch status {
+	case Coverage_GENERATED:
+		t.Generated++
+	case Coverage_DEPRECATED:
+		t.Deprecated++
+	case Coverage_SKIPPED:
+		t.Skipped++
+	case Coverage_UNSUPPORTED:
+		t.Unsupported++
+	}
+	t.Total++
+}
+
+// Count is the number of functions of the status.
+func (t *CoverageTotal) Count(status CoverageStatus) int {
+	switch status {
+	case Coverage_GENERATED:
+		return t.Generated
+	case Coverage_DEPRECATED:
+		return t.Deprecated
+	case Coverage_SKIPPED:
+		return t.Skipped
+	case Coverage_UNSUPPORTED:
+		return t.Unsupported
+	default:
+		return t.Total
+	}
+}
+
+// CoverageChange is a function added, removed, or with its status changed since the previous report.
+type CoverageChange struct {
+	CName string         `json:"c_name"`
+	From  CoverageStatus `json:"from,omitempty"` // empty for new functions
+	To    CoverageStatus `json:"to,omitempty"`   // empty for removed functions
+}
+
+// CoverageReport lists every function in mosek.h and whether it is wrapped.
+type CoverageReport struct {
+	Functions     []*CoverageEntry  `json:"functions"`
+	Totals        []*CoverageTotal  `json:"totals"`                   // by function type, and all at last
+	Changes       []*CoverageChange `json:"changes,omitempty"`        // since the previous report
+	PreviousTotal *CoverageTotal    `json:"previous_total,omitempty"` // totals of all the functions in the previous report
+}
+
+// All is the totals of all the functions.
+func (r *CoverageReport) All() *CoverageTotal {
+	return r.Totals[len(r.Totals)-1]
+}
+
+// coverageSummaryRow is a row of the summary in markdown.
+type coverageSummaryRow struct {
+	Status string
+	Count  int
+	Change string
+}
+
+// Summary is the number of functions of each status, and the change since the previous report.
+func (r *CoverageReport) Summary() []*coverageSummaryRow {
+	var rows []*coverageSummaryRow
+	all := r.All()
+	for _, s := range append(coverageStatuses, "total") {
+		row := &coverageSummaryRow{Status: string(s), Count: all.Count(s), Change: "-"}
+		if r.PreviousTotal != nil {
+			row.Change = fmt.Sprintf("%+d", all.Count(s)-r.PreviousTotal.Count(s))
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// ReadCoverageReport reads the json report written by [CoverageEmitter], which must end its totals with those of all the functions.
+func ReadCoverageReport(fileName string) (*CoverageReport, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	r := &CoverageReport{}
+	if err := json.Unmarshal(content, r); err != nil {
+		return nil, fmt.Errorf("failed to parse coverage report %s: %w", fileName, err)
+	}
+	if len(r.Totals) == 0 || r.All().FuncType != "all" {
+		return nil, fmt.Errorf("coverage report %s has no totals of all the functions", fileName)
+	}
+
+	return r, nil
+}
+
+// unsupportedCause detects why the generator cannot wrap the function, empty if it can.
+func unsupportedCause(f *mskh.MskFunction, config *OutputConfig) string {
+	if f.IsVariadic {
+		return "variadic function"
+	}
+	if _, found := config.TypeToGoType[f.ReturnType]; !found && f.ReturnType != "void" {
+		return fmt.Sprintf("unsupported return type %s", f.ReturnType)
+	}
+	for i, p := range f.Parameters {
+		if i == 0 && (p.Type == "MSKtask_t" || p.Type == "MSKenv_t") {
+			continue
+		}
+		base := strings.TrimPrefix(strings.TrimRight(p.Type, " *"), "const ")
+		if _, found := config.TypeToGoType[base]; !found {
+			return fmt.Sprintf("unsupported type %s of parameter %s", p.Type, p.Name)
+		}
+	}
+
+	return ""
+}
+
+// BuildCoverage builds the coverage report of the normalized config, compared with the previous report if it is not nil.
+func BuildCoverage(h *mskh.MosekH, config *OutputConfig, previous *CoverageReport) *CoverageReport {
+	r := &CoverageReport{}
+	totals := make([]*CoverageTotal, funcType_LAST)
+	for i := range totals {
+		totals[i] = &CoverageTotal{FuncType: funcType(i).String()}
+	}
+	all := &CoverageTotal{FuncType: "all"}
+
+	for _, f := range h.Functions {
+		fc, found := config.Funcs[f.Name]
+		if !found {
+			continue
+		}
+		entry := &CoverageEntry{CName: f.Name, FuncType: fc.FuncType.String()}
+		switch {
+		case fc.Skip:
+			cause := unsupportedCause(f, config)
+			entry.Status, entry.Reason = Coverage_SKIPPED, fc.SkipReason
+			if cause != "" {
+				entry.Status = Coverage_UNSUPPORTED
+			}
+			if entry.Reason == "" {
+				entry.Reason = cause
+			}
+			if entry.Reason == "" {
+				entry.Reason = "skipped by config without skip_reason"
+			}
+		case fc.IsDeprecated:
+			entry.Status, entry.Reason = Coverage_DEPRECATED, "deprecated by mosek"
+		default:
+			entry.Status = Coverage_GENERATED
+		}
+		if !fc.Skip {
+			entry.GoName = fc.GoName
+			entry.File = fc.FuncType.OutputFile()
+		}
+		totals[fc.FuncType].add(entry.Status)
+		all.add(entry.Status)
+		r.Functions = append(r.Functions, entry)
+	}
+	r.Totals = append(totals, all)
+
+	if previous != nil {
+		r.PreviousTotal = previous.All()
+		before := make(map[string]CoverageStatus)
+		for _, e := range previous.Functions {
+			before[e.CName] = e.Status
+		}
+		for _, e := range r.Functions {
+			if from, found := before[e.CName]; !found || from != e.Status {
+				r.Changes = append(r.Changes, &CoverageChange{CName: e.CName, From: from, To: e.Status})
+			}
+			delete(before, e.CName)
+		}
+		for _, e := range previous.Functions {
+			if from, found := before[e.CName]; found {
+				r.Changes = append(r.Changes, &CoverageChange{CName: e.CName, From: from})
+			}
+		}
+	}
+
+	return r
+}
+
+// CoverageEmitter is the [emit.Emitter] writing the coverage report into coverage.json and coverage.md.
+type CoverageEmitter struct {
+	h        *mskh.MosekH
+	config   *OutputConfig
+	previous *CoverageReport
+}
+
+var _ emit.Emitter = (*CoverageEmitter)(nil)
+
+// NewCoverageEmitter creates the emitter of the coverage report, previous is the last report to compare with, or nil.
+func NewCoverageEmitter(h *mskh.MosekH, config *OutputConfig, previous *CoverageReport) *CoverageEmitter {
+	return &CoverageEmitter{h: h, config: config, previous: previous}
+}
+
+func (e *CoverageEmitter) Name() string {
+	return "coverage"
+}
+
+func (e *CoverageEmitter) Emit(w emit.FileWriter) error {
+	r := BuildCoverage(e.h, e.config, e.previous)
+
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := w.WriteFile("coverage.json", b); err != nil {
+		return err
+	}
+
+	var md bytes.Buffer
+	if err := coverageFileTmpl.Execute(&md, r); err != nil {
+		return fmt.Errorf("failed to generate coverage report: %w", err)
+	}
+
+	return w.WriteFile("coverage.md", md.Bytes())
+}
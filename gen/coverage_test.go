@@ -0,0 +1,166 @@
+package gen
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fardream/gen-gmsk/mskh"
+)
+
+// coverageTestConfig normalizes the config for the test header, with some functions skipped without the reason in config.yml.
+func coverageTestConfig(t *testing.T) (*mskh.MosekH, *OutputConfig) {
+	t.Helper()
+	h, err := mskh.Parse(filepath.Join(gmskTestDir, "mosek.h"), &mskh.ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := NewOutputConfig()
+	if _, err := Normalize(h, config); err != nil {
+		t.Fatal(err)
+	}
+	config.Funcs["MSK_echotask"].SkipReason = ""
+	config.Funcs["MSK_getprimalobj"].Skip = true
+	config.Funcs["MSK_getaij"].Skip = true
+	config.Funcs["MSK_getaij"].SkipReason = "not needed"
+
+	return h, config
+}
+
+func TestBuildCoverage(t *testing.T) {
+	h, config := coverageTestConfig(t)
+	r := BuildCoverage(h, config, nil)
+
+	entries := make(map[string]*CoverageEntry)
+	for _, e := range r.Functions {
+		entries[e.CName] = e
+	}
+	for _, c := range []CoverageEntry{
+		{CName: "MSK_getversion", Status: Coverage_GENERATED, GoName: "GetVersion", FuncType: "other_funcs", File: "other_funcs.go"},
+		{CName: "MSK_getnumcone", Status: Coverage_DEPRECATED, Reason: "deprecated by mosek", GoName: "GetNumCone", FuncType: "task_getnum", File: "task_getnum.go"},
+		// skip_reason in config is preferred to the detected cause.
+		{CName: "MSK_writebsolutionhandle", Status: Coverage_UNSUPPORTED, Reason: "parameter func is a function pointer", FuncType: "task_other"},
+		{CName: "MSK_echotask", Status: Coverage_UNSUPPORTED, Reason: "variadic function", FuncType: "task_other"},
+		{CName: "MSK_getaij", Status: Coverage_SKIPPED, Reason: "not needed", FuncType: "task_get"},
+		{CName: "MSK_getprimalobj", Status: Coverage_SKIPPED, Reason: "skipped by config without skip_reason", FuncType: "task_get"},
+	} {
+		if e := entries[c.CName]; e == nil || *e != c {
+			t.Errorf("coverage of %s is %+v, expected %+v", c.CName, e, c)
+		}
+	}
+	if len(r.Functions) != len(h.Functions) {
+		t.Errorf("%d functions in the report, %d in mosek.h", len(r.Functions), len(h.Functions))
+	}
+
+	expected := make(map[string]*CoverageTotal)
+	all := &CoverageTotal{FuncType: "all"}
+	for _, e := range r.Functions {
+		if expected[e.FuncType] == nil {
+			expected[e.FuncType] = &CoverageTotal{FuncType: e.FuncType}
+		}
+		expected[e.FuncType].add(e.Status)
+		all.add(e.Status)
+	}
+	if len(r.Totals) != int(funcType_LAST)+1 {
+		t.Fatalf("%d totals, expected one for each function type and all", len(r.Totals))
+	}
+	for _, total := range r.Totals[:len(r.Totals)-1] {
+		e := expected[total.FuncType]
+		if e == nil {
+			e = &CoverageTotal{FuncType: total.FuncType}
+		}
+		if *total != *e {
+			t.Errorf("totals of %s are %+v, expected %+v", total.FuncType, total, e)
+		}
+	}
+	if *r.All() != *all {
+		t.Errorf("totals of all the functions are %+v, expected %+v", r.All(), all)
+	}
+	if r.Changes != nil || r.PreviousTotal != nil {
+		t.Errorf("changes without a previous report: %v %v", r.Changes, r.PreviousTotal)
+	}
+}
+
+func TestBuildCoverageChanges(t *testing.T) {
+	h, config := coverageTestConfig(t)
+	previous := &CoverageReport{
+		Functions: []*CoverageEntry{
+			{CName: "MSK_getversion", Status: Coverage_GENERATED},
+			{CName: "MSK_getaij", Status: Coverage_GENERATED},
+			{CName: "MSK_removed", Status: Coverage_GENERATED},
+		},
+		Totals: []*CoverageTotal{{FuncType: "all", Generated: 3, Total: 3}},
+	}
+	r := BuildCoverage(h, config, previous)
+
+	if r.PreviousTotal != previous.All() {
+		t.Errorf("previous total is %+v", r.PreviousTotal)
+	}
+	changes := make(map[string]CoverageChange)
+	for _, c := range r.Changes {
+		changes[c.CName] = *c
+	}
+	if _, found := changes["MSK_getversion"]; found {
+		t.Error("unchanged MSK_getversion is in the changes")
+	}
+	for _, c := range []CoverageChange{
+		{CName: "MSK_getaij", From: Coverage_GENERATED, To: Coverage_SKIPPED},
+		{CName: "MSK_removed", From: Coverage_GENERATED},
+		{CName: "MSK_getxx", To: Coverage_GENERATED},
+	} {
+		if changes[c.CName] != c {
+			t.Errorf("change of %s is %+v, expected %+v", c.CName, changes[c.CName], c)
+		}
+	}
+	// all the functions except MSK_getversion are new or changed, and MSK_removed is removed.
+	if len(r.Changes) != len(h.Functions) {
+		t.Errorf("%d changes, expected %d", len(r.Changes), len(h.Functions))
+	}
+}
+
+func TestReadCoverageReport(t *testing.T) {
+	h, config := coverageTestConfig(t)
+	files := make(memWriter)
+	if err := NewCoverageEmitter(h, config, nil).Emit(files); err != nil {
+		t.Fatal(err)
+	}
+	if md := string(files["coverage.md"]); !strings.Contains(md, "| `MSK_echotask` | unsupported |  |  | variadic function |") {
+		t.Errorf("MSK_echotask is not in coverage.md:\n%s", md)
+	}
+
+	dir := t.TempDir()
+	for name, content := range map[string][]byte{
+		"coverage.json":  files["coverage.json"],
+		"no_totals.json": []byte(`{"functions": [], "totals": []}`),
+		"invalid.json":   []byte(`{"functions": `),
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r, err := ReadCoverageReport(filepath.Join(dir, "coverage.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := BuildCoverage(h, config, nil); !reflect.DeepEqual(r, expected) {
+		b, _ := json.Marshal(r)
+		t.Errorf("read report is different from the written one:\n%s", b)
+	}
+
+	for _, c := range []struct {
+		file string
+		err  string
+	}{
+		{"no_totals.json", "has no totals of all the functions"},
+		{"invalid.json", "failed to parse coverage report"},
+		{"missing.json", "no such file"},
+	} {
+		if _, err := ReadCoverageReport(filepath.Join(dir, c.file)); err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%s: expected error with %q, got %v", c.file, c.err, err)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package gen
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/fardream/gen-gmsk/mskh"
+)
+
+// dlopenFunc is a function of mosek forwarded through the function pointer table of the dlopen backend.
+type dlopenFunc struct {
+	Name       string
+	ReturnType string
+	Params     string // declaration of the parameters
+	Args       string // names of the parameters, in the forwarding call
+}
+
+// NotLoaded is the C expression returned when the function is not loaded.
+func (f *dlopenFunc) NotLoaded() string {
+	switch f.ReturnType {
+	case "void":
+		return ""
+	case "MSKrescodee":
+		return "GMSK_RES_ERR_NOT_LOADED"
+	default:
+		return fmt.Sprintf("(%s)0", f.ReturnType)
+	}
+}
+
+var cArrayType = regexp.MustCompile(`^(.*)(\[\d*\])$`)
+
+// cParamDecl is the C declaration of a parameter, arrays have the size after the name.
+func cParamDecl(ctype, name string) string {
+	if m := cArrayType.FindStringSubmatch(ctype); m != nil {
+		return fmt.Sprintf("%s %s%s", m[1], name, m[2])
+	}
+	return fmt.Sprintf("%s %s", ctype, name)
+}
+
+// buildDlopenFuncs collects the functions in the header, variadic functions cannot be forwarded and are left out.
+func buildDlopenFuncs(h *mskh.MosekH) []*dlopenFunc {
+	var r []*dlopenFunc
+	for _, f := range h.Functions {
+		if f.IsVariadic {
+			continue
+		}
+		var params, args []string
+		for i, p := range f.Parameters {
+			name := p.Name
+			if name == "" {
+				name = fmt.Sprintf("p%d", i)
+			}
+			params = append(params, cParamDecl(p.Type, name))
+			args = append(args, name)
+		}
+		if len(params) == 0 {
+			params = []string{"void"}
+		}
+		r = append(r, &dlopenFunc{
+			Name:       f.Name,
+			ReturnType: f.ReturnType,
+			Params:     strings.Join(params, ", "),
+			Args:       strings.Join(args, ", "),
+		})
+	}
+
+	return r
+}
+
+type dlopenTmplInput struct {
+	*OutputConfig
+	Funcs []*dlopenFunc
+}
+
+// BuildDlopen generates the backend loading libmosek at runtime.
+func BuildDlopen(h *mskh.MosekH, config *OutputConfig, out io.Writer) error {
+	input := &dlopenTmplInput{OutputConfig: config, Funcs: buildDlopenFuncs(h)}
+
+	if err := dlopenFileTmpl.Execute(out, input); err != nil {
+		return fmt.Errorf("failed to generate dlopen backend: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/fardream/gen-gmsk/emit"
+	"github.com/fardream/gen-gmsk/mskh"
+)
+
+// rescodeEnum is the enum of response codes, which is generated as ResCode into rescodes.go.
+const rescodeEnum = "MSKrescode_enum"
+
+// GmskEmitter is the [emit.Emitter] generating the gmsk package.
+// It is generated from the header and the config, which must be normalized by [Normalize].
+type GmskEmitter struct {
+	h      *mskh.MosekH
+	config *OutputConfig
+}
+
+var _ emit.Emitter = (*GmskEmitter)(nil)
+
+// NewGmskEmitter creates the emitter of gmsk.
+func NewGmskEmitter(h *mskh.MosekH, config *OutputConfig) *GmskEmitter {
+	return &GmskEmitter{h: h, config: config}
+}
+
+func (e *GmskEmitter) Name() string {
+	return "gmsk"
+}
+
+// emitFile is a file generated by buildFunc.
+type emitFile struct {
+	name      string
+	buildFunc func(*mskh.MosekH, *OutputConfig, io.Writer) error
+}
+
+// file builds the file and writes it.
+func (e *GmskEmitter) file(w emit.FileWriter, name string, buildFunc func(*mskh.MosekH, *OutputConfig, io.Writer) error) error {
+	var content bytes.Buffer
+	if err := buildFunc(e.h, e.config, &content); err != nil {
+		return err
+	}
+
+	return w.WriteFile(name, content.Bytes())
+}
+
+// Emit writes the gmsk files. They are generated from the header and the config the model is built from,
+// TestModelMatchesEmitted checks the model declares the same functions and enums as the files.
+func (e *GmskEmitter) Emit(w emit.FileWriter) error {
+	config := e.config
+	for _, enumName := range e.h.EnumList {
+		enumData, ok := e.h.Enums[enumName]
+		if !ok {
+			return fmt.Errorf("enum %s is not found in parsed mosek.h", enumName)
+		}
+		ec, found := config.Enums[enumName]
+		if !found {
+			return fmt.Errorf("failed to find confing for enum: %s", enumName)
+		}
+		if ec.Skip {
+			continue
+		}
+		fileName := fmt.Sprintf("%s.go", strings.TrimPrefix(enumName, "MSK"))
+		if enumName == rescodeEnum {
+			fileName = "rescodes.go"
+		}
+		if err := e.file(w, fileName, func(h *mskh.MosekH, config *OutputConfig, out io.Writer) error {
+			return enumFileTmpl.Execute(out, &enumFileInput{
+				enumConfig:  ec,
+				CEnum:       enumData,
+				PkgName:     config.PackageName,
+				stripPrefix: enumConstantPrefix,
+			})
+		}); err != nil {
+			return err
+		}
+	}
+	if _, found := e.h.Enums[rescodeEnum]; !found {
+		return fmt.Errorf("failed to find %s from parsed mosek header", rescodeEnum)
+	}
+
+	files := []emitFile{
+		{"typed_params.go", BuildTypedParams},
+		{"typed_params_nocgo.go", BuildTypedParamStubs},
+		{"params.go", BuildParams},
+		{"params_nocgo.go", BuildParamsStubs},
+		{"solver_info.go", BuildSolverInfo},
+		{"solver_info_nocgo.go", BuildSolverInfoStubs},
+		{"solution.go", BuildSolution},
+		{"solution_nocgo.go", BuildSolutionStubs},
+		{path.Join(config.ParFilePackage, "parfile.go"), BuildParFile},
+		{"no_mosek.go", BuildNoMosek},
+		{"nocgo.go", BuildNoCgoTypes},
+		{"version.go", BuildVersion},
+	}
+	if config.DlopenBuildTag != "" {
+		files = append(files, emitFile{"dlopen.go", BuildDlopen})
+	}
+	for _, f := range files {
+		if err := e.file(w, f.name, f.buildFunc); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < int(funcType_LAST); i++ {
+		t := funcType(i)
+		if err := e.file(w, t.OutputFile(), func(mh *mskh.MosekH, oc *OutputConfig, w io.Writer) error {
+			return BuildFuncs(mh, oc, t, w)
+		}); err != nil {
+			return err
+		}
+		if err := e.file(w, t.StubOutputFile(), func(mh *mskh.MosekH, oc *OutputConfig, w io.Writer) error {
+			return BuildFuncStubs(mh, oc, t, w)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
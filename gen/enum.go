@@ -0,0 +1,82 @@
+package gen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fardream/gen-gmsk/mskh"
+)
+
+// enumConstantPrefix is the prefix removed from C enum constants to get the go names.
+const enumConstantPrefix = "MSK_"
+
+type enumConfig struct {
+	CommonId         `json:",inline"`
+	ConstantComments map[string]string `json:"constant_comments"`
+	IntegerType      string            `json:"integer_type"`
+	IsEqualType      bool              `json:"is_equal_type"`
+	ConstantPrefix   string            `json:"constant_prefix"`  // prepended to the go name of every constant
+	ConstantRenames  map[string]string `json:"constant_renames"` // C constant name -> go constant name
+}
+
+// ConstantGoName returns the go name of the enum constant, after removing stripPrefix,
+// applying the constant prefix and renames.
+func (ec *enumConfig) ConstantGoName(cname, stripPrefix string) string {
+	if v, found := ec.ConstantRenames[cname]; found {
+		return v
+	}
+
+	return ec.ConstantPrefix + strings.TrimPrefix(cname, stripPrefix)
+}
+
+type enumFileInput struct {
+	*enumConfig
+
+	CEnum *mskh.MskEnum
+
+	PkgName string
+
+	stripPrefix string
+}
+
+// CDeclaration is the position of the C declaration, empty if it is unknown.
+func (e *enumFileInput) CDeclaration() string {
+	return e.CEnum.Source.String()
+}
+
+func (e *enumFileInput) CName() string {
+	return e.CEnum.Name
+}
+
+func (e *enumFileInput) ConstantValues() []string {
+	if len(e.CEnum.Values) == 0 {
+		return nil
+	}
+
+	var r []string
+	for _, ev := range e.CEnum.Values {
+		constname := e.ConstantGoName(ev.Name, e.stripPrefix)
+		c, found := e.ConstantComments[ev.Name]
+		if found {
+			r = append(r, fmt.Sprintf("%s %s = %s // %s", constname, e.GoName, ev.Value, c))
+		} else {
+			r = append(r, fmt.Sprintf("%s %s = %s", constname, e.GoName, ev.Value))
+		}
+	}
+
+	return r
+}
+
+func (e *enumFileInput) ConstantMaps() []string {
+	if len(e.CEnum.Values) == 0 {
+		return nil
+	}
+
+	var r []string
+	for _, ev := range e.CEnum.Values {
+		constname := e.ConstantGoName(ev.Name, e.stripPrefix)
+		r = append(r, fmt.Sprintf("%s: \"%s\",", constname, constname))
+	}
+
+	return r
+}
@@ -1,4 +1,4 @@
-package main
+package gen
 
 import _ "embed"
 
@@ -21,7 +21,8 @@ type RustEnum struct {
 }
 
 type RustFunc struct {
-	Name       string `json:"name"`
-	Comment    string `json:"comment"`
-	StructName string `json:"struct_name"`
+	Name           string   `json:"name"`
+	Comment        string   `json:"comment"`
+	StructName     string   `json:"struct_name"`
+	NullableParams []string `json:"nullable_params"` // parameters passed as NULL by the rust binding when empty
 }
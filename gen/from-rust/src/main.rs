@@ -1,6 +1,7 @@
 use serde::{Deserialize, Serialize};
 use std::{collections::HashMap, fs::read_to_string};
-use syn::{Attribute, Expr, ImplItem, Item, Lit, Meta, Type};
+use quote::ToTokens;
+use syn::{Attribute, Expr, FnArg, ImplItem, ImplItemFn, Item, Lit, Meta, Pat, Type};
 
 #[derive(Default, Debug, Serialize, Deserialize)]
 struct MskEnumConst {
@@ -20,6 +21,8 @@ struct MskEnum {
 struct MskFunction {
     pub name: String,
     pub struct_name: String,
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub nullable_params: Vec<String>,
     pub comment: String,
 }
 
@@ -49,6 +52,33 @@ fn get_comment(attrs: &[Attribute]) -> String {
         .join("\n")
 }
 
+// nullable_params finds the parameters that can be NULL in the C function,
+// either Option<..> in the signature, or passed as NULL when empty.
+fn nullable_params(f: &ImplItemFn) -> Vec<String> {
+    let body = f.block.to_token_stream().to_string();
+    f.sig
+        .inputs
+        .iter()
+        .filter_map(|arg| {
+            let FnArg::Typed(pt) = arg else {
+                return None;
+            };
+            let Pat::Ident(pi) = pt.pat.as_ref() else {
+                return None;
+            };
+            let name = pi.ident.to_string();
+            let is_option = matches!(pt.ty.as_ref(), Type::Path(p) if p.path.segments.last().is_some_and(|s| s.ident == "Option"));
+            let null_if_empty =
+                body.contains(&format!("if {name} . len () == 0 {{ std :: ptr :: null_mut () }}"));
+            if is_option || null_if_empty {
+                Some(name.trim_end_matches('_').to_owned())
+            } else {
+                None
+            }
+        })
+        .collect()
+}
+
 fn is_enum(name: &str) -> bool {
     name != "Task" && name != "Env" && name != "TaskCB"
 }
@@ -116,6 +146,7 @@ fn main() {
                                 functions.push(MskFunction {
                                     name: f_name,
                                     struct_name: name.clone(),
+                                    nullable_params: nullable_params(&f),
                                     comment: f_comment,
                                 });
                             }
@@ -0,0 +1,718 @@
+package gen
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/fardream/gen-gmsk/mskh"
+)
+
+type funcType uint
+
+const (
+	// first one
+	funcType_NORMAL funcType = iota // other_funcs
+	// env
+	funcType_ENV // env
+	// task
+	funcType_TASK_PUT              // task_put
+	funcType_TASK_GET              // task_get
+	funcType_TASK_NAME             // task_name
+	funcType_TASK_GETNUM           // task_getnum
+	funcType_TASK_GETNUMNZ         // task_getnumnz
+	funcType_TASK_SLICETRIP        // task_slicetrip
+	funcType_TASK_APPEND           // task_append
+	funcType_TASK_APPENDDOMAIN     // task_apenddomain
+	funcType_TASK_GETLIST_OR_SLICE // task_getlist_or_slice
+	funcType_TASK_PUTLIST_OR_SLICE // task_putlist_or_slice
+	funcType_TASK_PUTMAXNUM        // task_putmaxnum
+	funcType_TASK_OTHER            // task_other
+	// this is the last so that we can iterate through the types
+	funcType_LAST // task_last
+)
+
+func (t funcType) OutputFile() string {
+	return fmt.Sprintf("%s.go", t.String())
+}
+
+type ParamConfig struct {
+	Name          string `json:"name"`             // name of the parameter in go
+	CName         string `json:"c_name"`           // name of the parameter in C
+	OrigCType     string `json:"orig_c_type"`      // Original C type
+	GoType        string `json:"go_type"`          // Mapped Go Type, without const and *
+	CgoType       string `json:"cgo_type"`         // Mapped CgoType, without *
+	IsPointer     bool   `json:"is_pointer"`       // is pointer
+	IsConst       bool   `json:"is_const"`         // is const
+	IsTask        bool   `json:"is_task"`          // task, and first parameter
+	IsEnv         bool   `json:"is_env"`           // env, and first parameter
+	IsStrOut      bool   `json:"is_str_out"`       // char * type, is output string
+	IsBoolOut     bool   `json:"is_bool_out"`      // bool * type, is output bool
+	IsStrArrayIn  bool   `json:"is_str_array_in"`  // const char ** type, input []string
+	IsAllocStrOut bool   `json:"is_alloc_str_out"` // MSKstring_t * type, output string allocated by mosek
+	IsAllocArrOut bool   `json:"is_alloc_arr_out"` // T ** type, output array allocated by mosek, copied to a slice of Length
+	FreesWith     string `json:"frees_with"`       // C function freeing the memory allocated by mosek
+	IsOutput      bool   `json:"is_output"`        // returned from the go function instead of being a parameter
+	Nullable      bool   `json:"nullable"`         // pointer can be NULL, nil slice is passed as NULL
+	Length        string `json:"length"`           // go expression of the length of output array
+
+	castViaUnsafe bool // go type is overridden and pointers need conversion through unsafe.Pointer
+}
+
+// IsOutputSlice is an output array, which is allocated with Length and returned.
+func (pc *ParamConfig) IsOutputSlice() bool {
+	return pc.IsOutput && pc.IsPointer && pc.Length != ""
+}
+
+// paramOverride changes how a parameter is processed, it is keyed by the C parameter name in [FuncConfig].
+type paramOverride struct {
+	Direction string `json:"direction"`  // in, out, or inout
+	GoType    string `json:"go_type"`    // go type without slice
+	GoName    string `json:"go_name"`    // name of the parameter in go
+	Nullable  *bool  `json:"nullable"`   // pointer parameter can be NULL, default is from the rust binding
+	Length    string `json:"length"`     // go expression of the length of output array, in terms of the go names of other parameters
+	FreesWith string `json:"frees_with"` // C function freeing the output string or array allocated by mosek, MSK_freetask or MSK_freeenv
+}
+
+type FuncConfig struct {
+	*CommonId `json:",inline"`
+
+	LastNParamOutput int                       `json:"last_n_param_output"`
+	FuncType         funcType                  `json:"func_type"`
+	ParamOverrides   map[string]*paramOverride `json:"params"`
+	Append           *appendConfig             `json:"append"`       // Append variant of the getter, inferred for list and slice getters
+	StrLenFunc       string                    `json:"str_len_func"` // C function returning the length of the output string, - to disable
+
+	params      []*ParamConfig
+	appendParam *ParamConfig // array filled by the Append variant
+	strLen      *strLenPair  // length function of the output string
+	nameSource  string       // where the go name comes from, config, rust, or rules
+	derivation  *nameDerivation
+}
+
+func (fc *FuncConfig) IsEnv() bool {
+	return fc.FuncType == funcType_ENV
+}
+
+func (fc *FuncConfig) IsTask() bool {
+	i := int(fc.FuncType)
+	return i > int(funcType_ENV) && i < int(funcType_LAST)
+}
+
+type FuncTmplInput struct {
+	*FuncConfig
+
+	CFunc *mskh.MskFunction
+
+	config *OutputConfig
+}
+
+func (t *FuncTmplInput) CName() string {
+	return t.CFunc.Name
+}
+
+// CDeclaration is the doc comment lines of the position and the prototype of the C declaration, empty if the position is unknown.
+func (t *FuncTmplInput) CDeclaration() []string {
+	src := t.CFunc.Source.String()
+	if src == "" {
+		return nil
+	}
+	r := []string{"// C declaration: " + src}
+	if t.CFunc.Prototype != "" {
+		r = append(r, "//", "//\t"+t.CFunc.Prototype)
+	}
+
+	return r
+}
+
+// GoParams return a list of strings that are parameters of golang functions.
+func (t *FuncTmplInput) GoParams() []string {
+	var r []string
+	for _, v := range t.params {
+		if v.IsTask || v.IsEnv || v.IsOutput || (t.strLen != nil && v == t.strLen.sizeParam) {
+			continue
+		}
+		var s string
+		switch {
+		case v.OrigCType == "const char *":
+			s = fmt.Sprintf("%s string", v.Name)
+		case v.OrigCType == "char *":
+			s = fmt.Sprintf("%s *byte", v.Name)
+		case v.IsStrArrayIn:
+			s = fmt.Sprintf("%s []string", v.Name)
+		case v.IsPointer:
+			s = fmt.Sprintf("%s []%s", v.Name, v.GoType)
+		default:
+			s = fmt.Sprintf("%s %s", v.Name, v.GoType)
+		}
+		r = append(r, s)
+	}
+
+	return r
+}
+
+func (t *FuncTmplInput) ExtraStdPkgs() []string {
+	pkgs := make(map[string]struct{})
+	if t.appendParam != nil {
+		pkgs["slices"] = struct{}{}
+	}
+	for _, pc := range t.params {
+		if pc.OrigCType == "const char *" || pc.OrigCType == "char *" || pc.castViaUnsafe || pc.Nullable ||
+			pc.IsStrArrayIn || pc.IsAllocStrOut || pc.IsAllocArrOut {
+			pkgs["unsafe"] = struct{}{}
+		}
+	}
+
+	return keys(pkgs)
+}
+
+func keys[T any](m map[string]T) []string {
+	var r []string
+	for k := range m {
+		r = append(r, k)
+	}
+
+	return r
+}
+
+// Outputs are the parameters returned from the go function.
+func (t *FuncTmplInput) Outputs() []*ParamConfig {
+	var r []*ParamConfig
+	for _, p := range t.params {
+		if p.IsOutput {
+			r = append(r, p)
+		}
+	}
+
+	return r
+}
+
+func (t *FuncTmplInput) HasOutputs() bool {
+	return len(t.Outputs()) > 0
+}
+
+func (t *FuncTmplInput) OutputStrings() []string {
+	var r []string
+	for _, p := range t.Outputs() {
+		if p.IsStrOut {
+			r = append(r, p.Name)
+		}
+	}
+
+	return r
+}
+
+// AllocOutputs are the output strings and arrays allocated by mosek, which are copied and freed with FreesWith.
+func (t *FuncTmplInput) AllocOutputs() []*ParamConfig {
+	var r []*ParamConfig
+	for _, p := range t.Outputs() {
+		if p.IsAllocStrOut || p.IsAllocArrOut {
+			r = append(r, p)
+		}
+	}
+
+	return r
+}
+
+// FreeCall is the cgo expression freeing the output allocated by mosek.
+func (t *FuncTmplInput) FreeCall(pc *ParamConfig) string {
+	owner := "task.task"
+	if t.IsEnv() {
+		owner = "env.getEnv()"
+	}
+
+	return fmt.Sprintf("C.%s(%s, unsafe.Pointer(c_%s))", pc.FreesWith, owner, pc.Name)
+}
+
+func (t *FuncTmplInput) OutputBools() []string {
+	var r []string
+	for _, p := range t.Outputs() {
+		if p.IsBoolOut {
+			r = append(r, p.Name)
+		}
+	}
+
+	return r
+}
+
+// OutputSlices are the output arrays allocated before calling the C function.
+func (t *FuncTmplInput) OutputSlices() []*ParamConfig {
+	var r []*ParamConfig
+	for _, p := range t.Outputs() {
+		if p.IsOutputSlice() {
+			r = append(r, p)
+		}
+	}
+
+	return r
+}
+
+// NullableNames are the go names of the nullable input arrays, for documentation.
+func (t *FuncTmplInput) NullableNames() string {
+	var r []string
+	for _, p := range t.NullableInputs() {
+		r = append(r, p.Name)
+	}
+
+	return strings.Join(r, ", ")
+}
+
+// NullableInputs are the input arrays passed as NULL when they are nil.
+func (t *FuncTmplInput) NullableInputs() []*ParamConfig {
+	var r []*ParamConfig
+	for _, p := range t.params {
+		if !p.IsOutput && p.IsPointer && p.Nullable {
+			r = append(r, p)
+		}
+	}
+
+	return r
+}
+
+// InputStringArrays are the []string inputs copied to arrays of C strings.
+func (t *FuncTmplInput) InputStringArrays() []*ParamConfig {
+	var r []*ParamConfig
+	for _, p := range t.params {
+		if p.IsStrArrayIn {
+			r = append(r, p)
+		}
+	}
+
+	return r
+}
+
+func (t *FuncTmplInput) InputStrings() []*ParamConfig {
+	var r []*ParamConfig
+	for _, p := range t.params {
+		if p.OrigCType == "const char *" {
+			r = append(r, p)
+		}
+	}
+
+	return r
+}
+
+// CCallInputs are the inputs to C function calls from cgo.
+func (t *FuncTmplInput) CCallInputs() []string {
+	var r []string
+	for i, pc := range t.params {
+		r = append(r, t.cCallInput(i, pc))
+	}
+
+	return r
+}
+
+// cCallInput is the cgo expression of the i-th parameter to the C function.
+func (t *FuncTmplInput) cCallInput(i int, pc *ParamConfig) string {
+	var s string
+	switch {
+	case i == 0 && t.IsEnv():
+		s = "env.getEnv()"
+	case i == 0 && t.IsTask():
+		s = "task.task"
+	case !pc.IsOutput && pc.OrigCType == "MSKbooleant":
+		s = fmt.Sprintf("boolToInt(%s)", pc.Name)
+	case pc.IsStrOut:
+		s = fmt.Sprintf("c_%s", pc.Name)
+	case pc.IsBoolOut:
+		s = fmt.Sprintf("&c_%s", pc.Name)
+	case pc.IsAllocStrOut || pc.IsAllocArrOut:
+		s = fmt.Sprintf("&c_%s", pc.Name)
+	case pc.IsStrArrayIn:
+		s = fmt.Sprintf("(**C.char)(getPtrToFirst(c_%s))", pc.Name)
+	case pc.IsOutputSlice():
+		s = pc.ptrToFirst()
+	case pc.IsOutput && pc.castViaUnsafe:
+		s = fmt.Sprintf("(*C.%s)(unsafe.Pointer(&%s))", pc.CgoType, pc.Name)
+	case pc.IsOutput:
+		s = fmt.Sprintf("(*C.%s)(&%s)", pc.CgoType, pc.Name)
+	case pc.OrigCType == "const char *":
+		s = fmt.Sprintf("c_%s", pc.Name)
+	case pc.OrigCType == "char *":
+		s = fmt.Sprintf("(*C.char)(unsafe.Pointer(%s))", pc.Name)
+	case pc.IsPointer && pc.Nullable:
+		s = fmt.Sprintf("c_%s", pc.Name)
+	case pc.IsPointer:
+		s = pc.ptrToFirst()
+	default:
+		s = fmt.Sprintf("C.%s(%s)", pc.CgoType, pc.Name)
+	}
+
+	return s
+}
+
+// ptrToFirst is the cgo expression of the pointer to the first element of the slice.
+func (pc *ParamConfig) ptrToFirst() string {
+	if pc.castViaUnsafe {
+		return fmt.Sprintf("(*C.%s)(unsafe.Pointer(getPtrToFirst(%s)))", pc.CgoType, pc.Name)
+	}
+	return fmt.Sprintf("(*C.%s)(getPtrToFirst(%s))", pc.CgoType, pc.Name)
+}
+
+func (t *FuncTmplInput) CReturnMapped() string {
+	if t.CFunc.ReturnType == "MSKbooleant" {
+		return "intToBool"
+	}
+
+	goTypeForC, found := t.config.TypeToGoType[t.CFunc.ReturnType]
+	if !found {
+		log.Printf("cannot find mapping for return type %s of %s", t.CFunc.ReturnType, t.CFunc.Ref())
+	}
+
+	return goTypeForC
+}
+
+// ReturnValueName is the name of the returned error, parameters are never named r after [goParamName].
+func (t *FuncTmplInput) ReturnValueName() string {
+	return "r"
+}
+
+func (t *FuncTmplInput) MapResToError() string {
+	if t.CFunc.ReturnType == "MSKrescodee" {
+		return ".ToError()"
+	} else {
+		return ""
+	}
+}
+
+func (t *FuncTmplInput) ReturnType() string {
+	outputs := t.Outputs()
+	if t.CFunc.ReturnType == "void" && len(outputs) == 0 {
+		return ""
+	}
+	goTypeForC, found := t.config.TypeToGoType[t.CFunc.ReturnType]
+	if !found {
+		log.Panicf("cannot find mapping for return type %s of %s", t.CFunc.ReturnType, t.CFunc.Ref())
+	}
+	if len(outputs) == 0 {
+		if goTypeForC == "ResCode" {
+			return "error"
+		} else {
+			return goTypeForC
+		}
+	}
+
+	returnValeus := []string{}
+	returnValueName := t.ReturnValueName()
+
+	for _, v := range outputs {
+		thisr := fmt.Sprintf("%s %s", v.Name, v.GoType)
+		switch {
+		case v.IsOutputSlice() || v.IsAllocArrOut:
+			thisr = fmt.Sprintf("%s []%s", v.Name, v.GoType)
+		case v.IsStrOut:
+			thisr = fmt.Sprintf("%s string", v.Name)
+		case v.IsBoolOut:
+			thisr = fmt.Sprintf("%s bool", v.Name)
+		}
+
+		returnValeus = append(returnValeus, thisr)
+	}
+
+	return fmt.Sprintf("(%s, %s error)", strings.Join(returnValeus, ", "), returnValueName)
+}
+
+func snakeToCamel(s string) string {
+	b := strings.Builder{}
+	for _, v := range strings.Split(s, "_") {
+		fmt.Fprint(&b, upperCaseFirstLetter(v))
+	}
+
+	return b.String()
+}
+
+func processRustComment(rustcomment string, config *OutputConfig) string {
+	r := make([]string, 0)
+	for _, v := range strings.Split(rustcomment, "\n") {
+		switch {
+		case strings.Contains(v, "# Argument"):
+			r = append(r, "\nArguments: ")
+		case strings.Contains(v, "_`") && strings.HasPrefix(v, "- `"):
+			r = append(r, fmt.Sprintf("  %s", strings.Replace(v, "_`", "`", len(v))))
+		case strings.HasPrefix(v, "- `"):
+			r = append(r, fmt.Sprintf("  %s", strings.Replace(v, "_`", "`", len(v))))
+		case strings.Contains(v, "# Returns"):
+			r = append(r, "\nReturns:")
+		case strings.HasPrefix(v, "See ["):
+			if len(r) > 0 && r[len(r)-1] == "" {
+				r = r[:len(r)-1]
+			}
+			continue
+		case strings.Contains(v, "Full documentation"):
+			continue
+		default:
+			r = append(r, v)
+		}
+	}
+
+	return strings.Join(r, "\n")
+}
+
+func setGoNameAndCommentFromRust(f *mskh.MskFunction, fc *FuncConfig, config *OutputConfig) {
+	if fc.GoName != "" && fc.Comment != "" {
+		return
+	}
+
+	rustfunc, found := config.mappedRustFuncs[f.Name]
+	if !found {
+		return
+	}
+
+	if fc.Comment == "" {
+		fc.Comment = processRustComment(rustfunc.Comment, config)
+	}
+
+	if fc.GoName == "" {
+		fc.GoName = snakeToCamel(rustfunc.Name)
+		fc.nameSource = "rust"
+	}
+}
+
+// classifyFunction finds the type of the function, which decides the output file.
+func classifyFunction(f *mskh.MskFunction, action, suffix string) funcType {
+	isTask := len(f.Parameters) >= 1 && f.Parameters[0].Type == "MSKtask_t"
+	isEnv := len(f.Parameters) >= 1 && f.Parameters[0].Type == "MSKenv_t"
+	switch {
+	case isEnv:
+		return funcType_ENV
+	case isTask && action == "PutMaxNum":
+		return funcType_TASK_PUTMAXNUM
+	case isTask && suffix == "SliceTrip":
+		return funcType_TASK_SLICETRIP
+	case isTask && (suffix == "Name" || suffix == "NameLen"):
+		return funcType_TASK_NAME
+	case isTask && (suffix == "NumNz" || suffix == "NumNz64") && action == "Get":
+		return funcType_TASK_GETNUMNZ
+	case isTask && action == "Append" && suffix == "Domain":
+		return funcType_TASK_APPENDDOMAIN
+	case isTask && action == "Append":
+		return funcType_TASK_APPEND
+	case isTask && action == "Get" && (suffix == "List" ||
+		suffix == "List64" || suffix == "Slice" || suffix == "SliceConst"):
+		return funcType_TASK_GETLIST_OR_SLICE
+	case isTask && action == "Get":
+		return funcType_TASK_GET
+	case isTask && action == "GetNum":
+		return funcType_TASK_GETNUM
+	case isTask && action == "Put" && (suffix == "List" ||
+		suffix == "List64" || suffix == "Slice" || suffix == "SliceConst"):
+		return funcType_TASK_PUTLIST_OR_SLICE
+	case isTask && action == "Put":
+		return funcType_TASK_PUT
+	case isTask:
+		return funcType_TASK_OTHER
+	default:
+		return funcType_NORMAL
+	}
+}
+
+func normalizeFunction(f *mskh.MskFunction, config *OutputConfig) {
+	fname := f.Name
+	d := config.Naming.derive(f.Name)
+	action, suffix := d.Action, d.Suffix
+
+	fc, found := config.Funcs[fname]
+	if !found {
+		fc = &FuncConfig{
+			CommonId: &CommonId{},
+		}
+		config.Funcs[f.Name] = fc
+	}
+	fc.derivation = d
+	// the type is also known for skipped functions, for the coverage report.
+	fc.FuncType = classifyFunction(f, action, suffix)
+
+	if fc.Skip {
+		return
+	}
+
+	if fc.GoName != "" {
+		fc.nameSource = "config"
+	}
+
+	setGoNameAndCommentFromRust(f, fc, config)
+
+	if fc.GoName == "" {
+		fc.GoName = d.Name
+		fc.nameSource = "rules"
+	}
+	if !fc.IsDeprecated {
+		_, isdes := config.Deprecated[fname]
+		fc.IsDeprecated = isdes
+	}
+	if fc.Url == "" {
+		url, found := config.Urls[fname]
+		if found {
+			fc.Url = url
+		} else {
+			fc.Url = "https://docs.mosek.com/latest/capi/alphabetic-functionalities.html"
+		}
+	}
+
+	IsTask := len(f.Parameters) >= 1 && f.Parameters[0].Type == "MSKtask_t"
+	IsEnv := len(f.Parameters) >= 1 && f.Parameters[0].Type == "MSKenv_t"
+
+	if action == "GetNum" && fc.LastNParamOutput == 0 {
+		fc.LastNParamOutput = 1
+	}
+	nparams := len(f.Parameters)
+	last_n_params := nparams - fc.LastNParamOutput
+	for i, p := range f.Parameters {
+		pc := &ParamConfig{Name: goParamName(p.Name, config), CName: p.Name, OrigCType: p.Type}
+		switch {
+		case i == 0 && IsEnv:
+			pc.IsEnv = true
+
+		case i == 0 && IsTask:
+			pc.IsTask = true
+
+		case i == nparams-1 && action == "GetMaxNum" && fc.LastNParamOutput == 0:
+			fallthrough
+		case i == nparams-1 && action == "GetNum" && fc.LastNParamOutput == 0:
+			fallthrough
+		case i == nparams-1 && fc.FuncType == funcType_TASK_APPENDDOMAIN && fc.LastNParamOutput == 0:
+			fallthrough
+		case i == nparams-1 && action == "Get" &&
+			(suffix == "NumNz" || suffix == "NumNz64") && fc.LastNParamOutput == 0:
+			fallthrough
+		case i == nparams-1 && action == "Get" && suffix == "NameLen" && fc.LastNParamOutput == 0:
+			fc.LastNParamOutput = 1
+			processParam(pc, p, config, f)
+
+		case i == nparams-1 && action == "Get" && suffix == "Name" && fc.LastNParamOutput == 0:
+			fc.LastNParamOutput = 1
+			pc.IsStrOut = true
+
+		case i == nparams-1 && action == "" && suffix == "ToStr" &&
+			fc.LastNParamOutput == 0 && p.Type == "char *":
+			fc.LastNParamOutput = 1
+			pc.IsStrOut = true
+
+		case i >= last_n_params && p.Type == "char *":
+			pc.IsStrOut = true
+
+		case i >= last_n_params && p.Type == "MSKbooleant *":
+			pc.IsBoolOut = true
+
+		case i >= last_n_params && p.Type == "MSKstring_t *":
+			pc.IsAllocStrOut = true
+			pc.GoType = "string"
+
+		case i >= last_n_params && strings.HasSuffix(p.Type, " * *"):
+			pc.IsAllocArrOut = true
+			pc.CgoType = strings.TrimSuffix(p.Type, " * *")
+			pc.GoType = config.TypeToGoType[pc.CgoType]
+
+		case p.Type == "const char * *":
+			pc.IsStrArrayIn = true
+			pc.IsPointer = true
+			pc.IsConst = true
+			pc.GoType = "string"
+
+		default:
+			processParam(pc, p, config, f)
+		}
+
+		fc.params = append(fc.params, pc)
+	}
+
+	var rustNullable []string
+	if rustfunc, found := config.mappedRustFuncs[f.Name]; found {
+		rustNullable = rustfunc.NullableParams
+	}
+	outputStart := nparams - fc.LastNParamOutput
+	for i, pc := range fc.params {
+		pc.IsOutput = i >= outputStart && !pc.IsTask && !pc.IsEnv
+		pc.Nullable = pc.IsPointer && !pc.IsOutput && pc.OrigCType != "char *" && !pc.IsStrArrayIn && slices.Contains(rustNullable, pc.CName)
+		if po, found := fc.ParamOverrides[pc.CName]; found {
+			applyParamOverride(pc, po, f.Parameters[i], config, f)
+		}
+		if (pc.IsAllocStrOut || pc.IsAllocArrOut) && pc.FreesWith == "" {
+			log.Panicf("parameter %s of %s is allocated by mosek, set frees_with to the function freeing it", pc.CName, f.Ref())
+		}
+		if pc.IsAllocArrOut && pc.Length == "" {
+			log.Panicf("parameter %s of %s is an array allocated by mosek, set length to copy it", pc.CName, f.Ref())
+		}
+	}
+
+	normalizeAppend(f, fc)
+}
+
+// freeFuncOwners are the functions freeing memory allocated by mosek, and the types of their first parameters.
+var freeFuncOwners = map[string]string{
+	"MSK_freetask": "MSKtask_t",
+	"MSK_freeenv":  "MSKenv_t",
+}
+
+// applyParamOverride applies the per parameter config.
+func applyParamOverride(pc *ParamConfig, po *paramOverride, p mskh.ParamDecl, config *OutputConfig, f *mskh.MskFunction) {
+	switch po.Direction {
+	case "":
+	case "out":
+		pc.IsOutput = true
+	case "in", "inout":
+		pc.IsOutput = false
+	default:
+		log.Panicf("unknown direction %s for parameter %s of %s", po.Direction, pc.CName, f.Ref())
+	}
+	if (po.Direction == "out" || po.Direction == "inout") && !isCPointer(p.Type) {
+		log.Panicf("parameter %s of %s is not a pointer and cannot be %s", pc.CName, f.Ref(), po.Direction)
+	}
+
+	pc.IsStrOut = pc.IsOutput && pc.OrigCType == "char *"
+	pc.IsBoolOut = pc.IsOutput && pc.OrigCType == "MSKbooleant *"
+	if !pc.IsStrOut && !pc.IsBoolOut && !pc.IsAllocStrOut && !pc.IsAllocArrOut && !pc.IsStrArrayIn && pc.CgoType == "" {
+		processParam(pc, p, config, f)
+	}
+
+	if po.GoName != "" {
+		if isUnsafeParamName(po.GoName, config) {
+			log.Panicf("go_name %s of parameter %s of %s is a keyword, a predeclared or a reserved name", po.GoName, pc.CName, f.Ref())
+		}
+		pc.Name = po.GoName
+	}
+	if po.GoType != "" && po.GoType != pc.GoType {
+		pc.GoType = po.GoType
+		pc.castViaUnsafe = true
+	}
+	if po.Nullable != nil {
+		if *po.Nullable && !pc.IsPointer {
+			log.Panicf("parameter %s of %s is not a pointer and cannot be nullable", pc.CName, f.Ref())
+		}
+		pc.Nullable = *po.Nullable
+	}
+	if po.FreesWith != "" {
+		if (!pc.IsAllocStrOut && !pc.IsAllocArrOut) || !pc.IsOutput {
+			log.Panicf("frees_with is only for outputs allocated by mosek, but parameter %s of %s is not", pc.CName, f.Ref())
+		}
+		if owner, found := freeFuncOwners[po.FreesWith]; !found || f.Parameters[0].Type != owner {
+			log.Panicf("parameter %s of %s cannot be freed with %s", pc.CName, f.Ref(), po.FreesWith)
+		}
+		pc.FreesWith = po.FreesWith
+	}
+	if po.Length != "" {
+		if !pc.IsOutput || (!pc.IsPointer && !pc.IsAllocArrOut) {
+			log.Panicf("length is only for output arrays, but parameter %s of %s is not", pc.CName, f.Ref())
+		}
+		pc.Length = po.Length
+	}
+}
+
+// isCPointer checks the C type of a parameter is a pointer, which an output is returned through.
+func isCPointer(cType string) bool {
+	return strings.HasSuffix(cType, "*")
+}
+
+func processParam(pc *ParamConfig, p mskh.ParamDecl, config *OutputConfig, f *mskh.MskFunction) {
+	pc.IsPointer = strings.HasSuffix(p.Type, " *")
+	ctype := strings.TrimSuffix(p.Type, " *")
+	pc.IsConst = strings.HasPrefix(p.Type, "const ")
+	ctype = strings.TrimPrefix(ctype, "const ")
+	pc.CgoType = ctype
+	found := false
+	pc.GoType, found = config.TypeToGoType[ctype]
+	if !found {
+		log.Printf("cannot find func: %s", f.Name)
+	}
+}
@@ -1,6 +1,6 @@
 // Code generated by "stringer -type=funcType -linecomment"; DO NOT EDIT.
 
-package main
+package gen
 
 import "strconv"
 
@@ -1,4 +1,4 @@
-package main
+package gen
 
 //go:generate stringer -type=funcType -linecomment
-//go:generate go run ./get-doc
+//go:generate go run ../get-doc
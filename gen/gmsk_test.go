@@ -0,0 +1,120 @@
+package gen
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fardream/gen-gmsk/emit"
+	"github.com/fardream/gen-gmsk/mskh"
+)
+
+// gmskTestDir is the fake mosek.h, the stubs of the hand-written parts of gmsk, and the tests of the generated code.
+const gmskTestDir = "testdata/gmsk"
+
+// generateTestGmsk generates gmsk from the fake mosek.h into a temporary module, together with the files in testdata/gmsk.
+// The functions not implemented by fake_mosek.c are weak stubs failing with MSK_RES_ERR_LICENSE.
+func generateTestGmsk(t *testing.T) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("builds the generated package")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go is not found")
+	}
+
+	h, err := mskh.Parse(filepath.Join(gmskTestDir, "mosek.h"), &mskh.ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := NewOutputConfig()
+	if _, err := Normalize(h, config); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := emit.Run(&emit.DirWriter{Dir: dir, ModulePath: "github.com/fardream/gmsk/v11"}, NewGmskEmitter(h, config)); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(gmskTestDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		content, err := os.ReadFile(filepath.Join(gmskTestDir, e.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, e.Name()), content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stubs strings.Builder
+	stubs.WriteString("//go:build !gmsk_dlopen\n\n// weak stubs of the functions in mosek.h\n\n#include <mosek.h>\n")
+	for _, f := range h.Functions {
+		fmt.Fprintf(&stubs, "\n__attribute__((weak)) %s\n{\n    return (%s)MSK_RES_ERR_LICENSE;\n}\n", strings.TrimSuffix(f.Prototype, ";"), f.ReturnType)
+	}
+	for name, content := range map[string]string{
+		"go.mod":  "module github.com/fardream/gmsk/v11\n\ngo 1.21\n",
+		"stubs.c": stubs.String(),
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := CheckMakeEnvHook(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+// runGo runs the go command in dir, env is added to the environment.
+func runGo(t *testing.T, dir string, env []string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=", "GOWORK=off", "CGO_CFLAGS=-I"+dir)
+	cmd.Env = append(cmd.Env, env...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go %s %s failed: %s\n%s", strings.Join(env, " "), strings.Join(args, " "), err, output)
+	}
+}
+
+// buildFakeLib builds fake_mosek.c and the stubs in dir into a shared object for the dlopen backend.
+func buildFakeLib(t *testing.T, dir string) string {
+	t.Helper()
+	cc, err := exec.LookPath("cc")
+	if err != nil {
+		t.Skip("cc is not found")
+	}
+	lib := filepath.Join(dir, "libfakemosek.so")
+	cmd := exec.Command(cc, "-shared", "-fPIC", "-I", dir, "-o", lib, "fake_mosek.c", "stubs.c")
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("failed to build %s: %s\n%s", lib, err, output)
+	}
+
+	return lib
+}
+
+func TestGeneratedGmsk(t *testing.T) {
+	dir := generateTestGmsk(t)
+
+	t.Run("vet", func(t *testing.T) { runGo(t, dir, nil, "vet", "./...") })
+	t.Run("vet dlopen", func(t *testing.T) { runGo(t, dir, nil, "vet", "-tags", "gmsk_dlopen", ".") })
+	t.Run("vet nocgo", func(t *testing.T) { runGo(t, dir, []string{"CGO_ENABLED=0"}, "vet", ".") })
+	t.Run("test", func(t *testing.T) {
+		runGo(t, dir, nil, "test", "-ldflags=-linkmode=external", "-bench", ".", "-benchtime", "100x", ".")
+	})
+	t.Run("test nocgo", func(t *testing.T) { runGo(t, dir, []string{"CGO_ENABLED=0"}, "test", ".") })
+	t.Run("test dlopen", func(t *testing.T) {
+		lib := buildFakeLib(t, dir)
+		runGo(t, dir, []string{"GMSK_TEST_LIB=" + lib}, "test", "-tags", "gmsk_dlopen", "-run", "TestDlopen", ".")
+	})
+}
@@ -0,0 +1,320 @@
+package gen
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/fardream/gen-gmsk/mskh"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+)
+
+// yamlKeyLines maps the dotted path of each mapping key in a yaml document to its line number.
+type yamlKeyLines map[string]int
+
+func newYamlKeyLines(content []byte) (yamlKeyLines, error) {
+	f, err := parser.ParseBytes(content, 0)
+	if err != nil {
+		return nil, err
+	}
+	r := make(yamlKeyLines)
+	for _, doc := range f.Docs {
+		r.add("", doc.Body)
+	}
+
+	return r, nil
+}
+
+func (l yamlKeyLines) add(prefix string, node ast.Node) {
+	var values []*ast.MappingValueNode
+	switch n := node.(type) {
+	case *ast.MappingNode:
+		values = n.Values
+	case *ast.MappingValueNode:
+		values = []*ast.MappingValueNode{n}
+	default:
+		return
+	}
+
+	for _, v := range values {
+		key := v.Key.String()
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		l[key] = v.Key.GetToken().Position.Line
+		l.add(key, v.Value)
+	}
+}
+
+// line returns the line number of the key, or the closest parent if it is not found.
+func (l yamlKeyLines) line(key string) int {
+	for key != "" {
+		if v, found := l[key]; found {
+			return v
+		}
+		i := strings.LastIndex(key, ".")
+		if i < 0 {
+			break
+		}
+		key = key[:i]
+	}
+
+	return 0
+}
+
+// LintProblem is a problem found in config.
+type LintProblem struct {
+	Key     string
+	Line    int
+	Message string
+	Decl    string // file:line of the C declaration the problem is about, empty if unknown
+}
+
+func (p *LintProblem) String() string {
+	if p.Decl != "" {
+		return fmt.Sprintf("config.yml:%d: %s: %s (C declaration: %s)", p.Line, p.Key, p.Message, p.Decl)
+	}
+	return fmt.Sprintf("config.yml:%d: %s: %s", p.Line, p.Key, p.Message)
+}
+
+type configLinter struct {
+	lines    yamlKeyLines
+	problems []*LintProblem
+}
+
+func (c *configLinter) report(key string, format string, args ...any) {
+	c.reportAt(key, mskh.Source{}, format, args...)
+}
+
+// reportAt reports a problem about the C declaration at src.
+func (c *configLinter) reportAt(key string, src mskh.Source, format string, args ...any) {
+	c.problems = append(c.problems, &LintProblem{
+		Key:     key,
+		Line:    c.lines.line(key),
+		Message: fmt.Sprintf(format, args...),
+		Decl:    src.String(),
+	})
+}
+
+// lintConfig cross checks the config entries against the parsed header.
+func lintConfig(h *mskh.MosekH, config *OutputConfig, lines yamlKeyLines) []*LintProblem {
+	c := &configLinter{lines: lines}
+
+	functions := make(map[string]*mskh.MskFunction)
+	for _, f := range h.Functions {
+		functions[f.Name] = f
+	}
+
+	for _, name := range sortedKeys(config.Enums) {
+		ec := config.Enums[name]
+		key := fmt.Sprintf("enums.%s", name)
+		e, found := h.Enums[name]
+		if !found {
+			c.report(key, "enum is not found in mosek.h")
+			continue
+		}
+		values := make(map[string]struct{})
+		for _, v := range e.Values {
+			values[v.Name] = struct{}{}
+		}
+		for _, cname := range sortedKeys(ec.ConstantComments) {
+			if _, found := values[cname]; !found {
+				c.reportAt(fmt.Sprintf("%s.constant_comments.%s", key, cname), e.Source, "constant is not in %s", name)
+			}
+		}
+		for _, cname := range sortedKeys(ec.ConstantRenames) {
+			if _, found := values[cname]; !found {
+				c.reportAt(fmt.Sprintf("%s.constant_renames.%s", key, cname), e.Source, "constant is not in %s", name)
+			}
+		}
+	}
+
+	goNames := make(map[string][]string)
+	for _, name := range sortedKeys(config.Funcs) {
+		fc := config.Funcs[name]
+		key := fmt.Sprintf("funcs.%s", name)
+		f, found := functions[name]
+		if !found {
+			c.report(key, "function is not found in mosek.h")
+			continue
+		}
+		nparams := len(f.Parameters)
+		if nparams > 0 && (f.Parameters[0].Type == "MSKtask_t" || f.Parameters[0].Type == "MSKenv_t") {
+			nparams--
+		}
+		if fc.LastNParamOutput > nparams {
+			c.reportAt(key+".last_n_param_output", f.Source, "%d is more than the %d parameters of the function", fc.LastNParamOutput, nparams)
+		}
+		for _, pname := range sortedKeys(fc.ParamOverrides) {
+			i := slices.IndexFunc(f.Parameters, func(p mskh.ParamDecl) bool { return p.Name == pname })
+			if i < 0 {
+				c.reportAt(fmt.Sprintf("%s.params.%s", key, pname), f.Source, "parameter is not found in %s", name)
+				continue
+			}
+			if d := fc.ParamOverrides[pname].Direction; (d == "out" || d == "inout") && !isCPointer(f.Parameters[i].Type) {
+				c.reportAt(fmt.Sprintf("%s.params.%s.direction", key, pname), f.Source, "%s is not a pointer and cannot be %s", f.Parameters[i].Type, d)
+			}
+		}
+		_, isDeprecated := config.Deprecated[name]
+		if fc.Skip && isDeprecated {
+			c.reportAt(key+".skip", f.Source, "skip is redundant, the function is deprecated")
+		}
+		if fc.SkipReason != "" && !fc.Skip {
+			c.reportAt(key+".skip_reason", f.Source, "skip_reason is set but the function is not skipped")
+		}
+		if fc.IsDeprecated && isDeprecated {
+			c.reportAt(key+".is_deprecated", f.Source, "is_deprecated is redundant, the function is in deprecated.yml")
+		}
+		if fc.GoName != "" {
+			// methods of Task and Env, and package level functions can share go names.
+			goName := fc.GoName
+			switch {
+			case len(f.Parameters) > 0 && f.Parameters[0].Type == "MSKtask_t":
+				goName = "Task." + goName
+			case len(f.Parameters) > 0 && f.Parameters[0].Type == "MSKenv_t":
+				goName = "Env." + goName
+			}
+			goNames[goName] = append(goNames[goName], name)
+		}
+	}
+	for _, goName := range sortedKeys(goNames) {
+		names := goNames[goName]
+		for _, name := range names[1:] {
+			c.report(fmt.Sprintf("funcs.%s.go_name", name), "%s duplicates the go_name of %s", goName, names[0])
+		}
+	}
+
+	intParams := make(map[string]struct{})
+	if e, found := h.Enums["MSKiparam_enum"]; found {
+		for _, v := range e.Values {
+			intParams[v.Name] = struct{}{}
+		}
+	}
+	for _, name := range sortedKeys(config.ParamValueEnums) {
+		key := fmt.Sprintf("param_value_enums.%s", name)
+		if _, found := intParams[name]; !found {
+			c.report(key, "integer parameter is not found in mosek.h")
+		}
+		if enumName := config.ParamValueEnums[name]; enumName != "" {
+			if _, found := h.Enums[enumName]; !found {
+				c.report(key, "enum %s is not found in mosek.h", enumName)
+			}
+		}
+	}
+
+	for i, rule := range config.ParamDocRules {
+		if _, found := h.Enums[rule.Enum]; !found {
+			c.report("param_doc_rules", "enum %s of rule %d is not found in mosek.h", rule.Enum, i)
+		}
+	}
+
+	infoItems := make(map[string]struct{})
+	for _, ik := range infoKinds {
+		if e, found := h.Enums[ik.enumName]; found {
+			for _, v := range e.Values {
+				infoItems[v.Name] = struct{}{}
+			}
+		}
+	}
+	for _, name := range sortedKeys(config.InfoFieldRenames) {
+		if _, found := infoItems[name]; !found {
+			c.report(fmt.Sprintf("info_field_renames.%s", name), "information item is not found in mosek.h")
+		}
+	}
+
+	knownTypes := make(map[string]struct{})
+	for k, v := range h.Typedefs {
+		knownTypes[k] = struct{}{}
+		knownTypes[stripCTypePrefix(v)] = struct{}{}
+	}
+	for k := range h.Enums {
+		knownTypes[k] = struct{}{}
+	}
+	for _, f := range h.Functions {
+		knownTypes[f.ReturnType] = struct{}{}
+		for _, p := range f.Parameters {
+			knownTypes[strings.TrimPrefix(strings.TrimRight(p.Type, " *"), "const ")] = struct{}{}
+		}
+	}
+	for _, name := range sortedKeys(config.TypeToGoType) {
+		key := fmt.Sprintf("type_to_go_type.%s", name)
+		// the builtin mappings are not from config.yml
+		if _, inConfig := lines[key]; !inConfig {
+			continue
+		}
+		if _, found := knownTypes[name]; !found {
+			c.report(key, "type is not used in mosek.h")
+		}
+	}
+
+	return c.problems
+}
+
+// numericRangeDoc matches documentation hinting the parameter takes a range of integers instead of on and off.
+var numericRangeDoc = regexp.MustCompile(`(?i)\d|\b(level|between|range|percent(age)?|number of|amount)\b`)
+
+// lintParamDocRules reports the integer parameters matched by param_doc_rules whose documentation mentions a numeric range,
+// config must be normalized for the documentation of the parameters.
+func lintParamDocRules(h *mskh.MosekH, config *OutputConfig, lines yamlKeyLines) []*LintProblem {
+	c := &configLinter{lines: lines}
+	e, found := h.Enums["MSKiparam_enum"]
+	ec, inConfig := config.Enums["MSKiparam_enum"]
+	if !found || !inConfig {
+		return nil
+	}
+	for _, v := range e.Values {
+		if _, found := config.ParamValueEnums[v.Name]; found {
+			continue
+		}
+		comment := ec.ConstantComments[v.Name]
+		for i, rule := range config.ParamDocRules {
+			if !rule.re.MatchString(comment) {
+				continue
+			}
+			if numericRangeDoc.MatchString(comment) {
+				c.reportAt("param_doc_rules", v.Source, "rule %d matches %s, but its documentation %q mentions a numeric range, map it to \"\" in param_value_enums", i, v.Name, comment)
+			}
+			break
+		}
+	}
+
+	return c.problems
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	r := keys(m)
+	slices.Sort(r)
+	return r
+}
+
+// Lint checks the embedded config.yml against the parsed header, including the go identifiers declared more than once.
+func Lint(h *mskh.MosekH) ([]*LintProblem, error) {
+	lines, err := newYamlKeyLines(configStr)
+	if err != nil {
+		return nil, err
+	}
+	problems := lintConfig(h, NewOutputConfig(), lines)
+
+	// collisions are only known after normalization.
+	config := NewOutputConfig()
+	if err := normalize(h, config); err != nil {
+		return nil, err
+	}
+	problems = append(problems, lintParamDocRules(h, config, lines)...)
+	for _, col := range findCollisions(h, config) {
+		for _, id := range col.Idents {
+			if id.Kind == "method" || id.Kind == "function" {
+				problems = append(problems, &LintProblem{
+					Key:     fmt.Sprintf("funcs.%s", id.Origin),
+					Line:    lines.line(fmt.Sprintf("funcs.%s", id.Origin)),
+					Message: col.String(),
+					Decl:    id.Source,
+				})
+			}
+		}
+	}
+
+	return problems, nil
+}
@@ -0,0 +1,104 @@
+package gen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fardream/gen-gmsk/mskh"
+)
+
+func TestLintGoNameDuplicates(t *testing.T) {
+	h := mskh.NewMosekH()
+	for _, f := range []struct{ name, receiver string }{
+		{"MSK_taskfoo", "MSKtask_t"},
+		{"MSK_envfoo", "MSKenv_t"},
+		{"MSK_foo", "MSKint32t"},
+		{"MSK_taskfoo2", "MSKtask_t"},
+	} {
+		h.Functions = append(h.Functions, &mskh.MskFunction{
+			Name:       f.name,
+			ReturnType: "MSKrescodee",
+			Parameters: []mskh.ParamDecl{{Name: "x", Type: f.receiver}},
+		})
+	}
+	config := &OutputConfig{Funcs: make(map[string]*FuncConfig)}
+	for _, name := range []string{"MSK_taskfoo", "MSK_envfoo", "MSK_foo", "MSK_taskfoo2"} {
+		config.Funcs[name] = &FuncConfig{CommonId: &CommonId{GoName: "Foo"}}
+	}
+
+	problems := lintConfig(h, config, yamlKeyLines{})
+	if len(problems) != 1 {
+		t.Fatalf("expected 1 problem, got %v", problems)
+	}
+	if p := problems[0]; p.Key != "funcs.MSK_taskfoo2.go_name" || !strings.Contains(p.Message, "Task.Foo duplicates the go_name of MSK_taskfoo") {
+		t.Errorf("unexpected problem %s", p)
+	}
+}
+
+func TestLintParamDirection(t *testing.T) {
+	h := mskh.NewMosekH()
+	h.Functions = append(h.Functions, &mskh.MskFunction{
+		Name:       "MSK_getfoo",
+		ReturnType: "MSKrescodee",
+		Parameters: []mskh.ParamDecl{
+			{Name: "task", Type: "MSKtask_t"},
+			{Name: "i", Type: "MSKint32t"},
+			{Name: "x", Type: "MSKrealt *"},
+			{Name: "y", Type: "MSKrealt *"},
+		},
+	})
+	config := &OutputConfig{Funcs: map[string]*FuncConfig{
+		"MSK_getfoo": {CommonId: &CommonId{}, ParamOverrides: map[string]*paramOverride{
+			"i": {Direction: "out"},
+			"x": {Direction: "inout"},
+			"y": {Direction: "out"},
+		}},
+	}}
+
+	problems := lintConfig(h, config, yamlKeyLines{})
+	if len(problems) != 1 {
+		t.Fatalf("expected 1 problem, got %v", problems)
+	}
+	if p := problems[0]; p.Key != "funcs.MSK_getfoo.params.i.direction" || !strings.Contains(p.Message, "MSKint32t is not a pointer") {
+		t.Errorf("unexpected problem %s", p)
+	}
+}
+
+func TestLintParamDocRules(t *testing.T) {
+	h := mskh.NewMosekH()
+	h.Enums["MSKiparam_enum"] = (&mskh.MskEnum{Name: "MSKiparam_enum"}).
+		AddValue("MSK_IPAR_X_USE", "0").
+		AddValue("MSK_IPAR_X_LEVEL", "1").
+		AddValue("MSK_IPAR_SIM_DUAL_CRASH", "2")
+	config := NewOutputConfig()
+	config.Enums["MSKiparam_enum"].ConstantComments = map[string]string{
+		"MSK_IPAR_X_USE":          "Controls whether x is used.",
+		"MSK_IPAR_X_LEVEL":        "Controls whether x is used, and the level of x between 0 and 100.",
+		"MSK_IPAR_SIM_DUAL_CRASH": "Controls whether crashing is performed, 90 is the default.", // mapped to "" in param_value_enums
+	}
+
+	problems := lintParamDocRules(h, config, yamlKeyLines{})
+	if len(problems) != 1 {
+		t.Fatalf("expected 1 problem, got %v", problems)
+	}
+	if p := problems[0]; p.Key != "param_doc_rules" || !strings.Contains(p.Message, "MSK_IPAR_X_LEVEL") {
+		t.Errorf("unexpected problem %s", p)
+	}
+}
+
+func TestLintParamDocRuleEnums(t *testing.T) {
+	h := mskh.NewMosekH()
+	h.Enums["MSKonoffkey_enum"] = &mskh.MskEnum{Name: "MSKonoffkey_enum"}
+	config := &OutputConfig{ParamDocRules: []*paramDocRule{
+		{Pattern: "whether", Enum: "MSKonoffkey_enum"},
+		{Pattern: "basis", Enum: "MSKbasindtype_enum"},
+	}}
+
+	problems := lintConfig(h, config, yamlKeyLines{})
+	if len(problems) != 1 {
+		t.Fatalf("expected 1 problem, got %v", problems)
+	}
+	if p := problems[0]; p.Key != "param_doc_rules" || !strings.Contains(p.Message, "MSKbasindtype_enum of rule 1") {
+		t.Errorf("unexpected problem %s", p)
+	}
+}
@@ -0,0 +1,132 @@
+package gen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fardream/gen-gmsk/model"
+	"github.com/fardream/gen-gmsk/mskh"
+)
+
+// Normalize applies the config to the header and builds the API model.
+// It fails if go identifiers are declared more than once.
+func Normalize(h *mskh.MosekH, config *OutputConfig) (*model.API, error) {
+	if err := normalize(h, config); err != nil {
+		return nil, err
+	}
+
+	if collisions := findCollisions(h, config); len(collisions) > 0 {
+		var lines []string
+		for _, c := range collisions {
+			lines = append(lines, fmt.Sprintf("collision: %s", c))
+		}
+		return nil, fmt.Errorf("%d go identifiers are declared more than once, rename them with go_name, constant_prefix, constant_renames or info_field_renames in config:\n%s", len(collisions), strings.Join(lines, "\n"))
+	}
+
+	return buildModel(h, config), nil
+}
+
+// buildModel converts the normalized config into the API model.
+func buildModel(h *mskh.MosekH, config *OutputConfig) *model.API {
+	api := &model.API{PackageName: config.PackageName}
+
+	for _, enumName := range h.EnumList {
+		e := h.Enums[enumName]
+		ec, found := config.Enums[enumName]
+		if !found || ec.Skip {
+			continue
+		}
+		me := &model.Enum{
+			CName:       enumName,
+			GoName:      ec.GoName,
+			IntegerType: ec.IntegerType,
+			IsAlias:     ec.IsEqualType,
+			Doc:         ec.Comment,
+			Source:      e.Source.String(),
+		}
+		for _, ev := range e.Values {
+			me.Constants = append(me.Constants, &model.Constant{
+				CName:   ev.Name,
+				GoName:  ec.ConstantGoName(ev.Name, enumConstantPrefix),
+				Value:   ev.Value,
+				Comment: ec.ConstantComments[ev.Name],
+				Source:  ev.Source.String(),
+			})
+		}
+		api.Enums = append(api.Enums, me)
+	}
+
+	for _, f := range h.Functions {
+		fc, found := config.Funcs[f.Name]
+		if !found || fc.Skip {
+			continue
+		}
+		mf := &model.Function{
+			CName:      f.Name,
+			GoName:     fc.GoName,
+			FuncType:   fc.FuncType.String(),
+			Doc:        fc.Comment,
+			URL:        fc.Url,
+			Deprecated: fc.IsDeprecated,
+			ReturnType: config.TypeToGoType[f.ReturnType],
+			Source:     f.Source.String(),
+			Prototype:  f.Prototype,
+		}
+		switch {
+		case fc.IsEnv():
+			mf.Receiver = "Env"
+		case fc.IsTask():
+			mf.Receiver = "Task"
+		}
+		for _, pc := range fc.params {
+			if pc.IsTask || pc.IsEnv {
+				continue
+			}
+			mp := modelParam(pc)
+			if fc.strLen != nil && pc == fc.strLen.sizeParam {
+				mp.SizeFunc = fc.strLen.lenCName
+			}
+			mf.Params = append(mf.Params, mp)
+		}
+		api.Functions = append(api.Functions, mf)
+	}
+
+	return api
+}
+
+func modelParam(pc *ParamConfig) *model.Param {
+	p := &model.Param{
+		CName:     pc.CName,
+		CType:     pc.OrigCType,
+		GoName:    pc.Name,
+		GoType:    pc.GoType,
+		Direction: model.In,
+		Nullable:  pc.Nullable,
+		Length:    pc.Length,
+		FreesWith: pc.FreesWith,
+	}
+	if pc.IsOutput {
+		p.Direction = model.Out
+	}
+
+	switch {
+	case pc.IsAllocStrOut:
+		p.Kind = model.AllocString
+	case pc.IsAllocArrOut:
+		p.Kind = model.AllocSlice
+	case pc.IsStrArrayIn:
+		p.Kind = model.StringArray
+	case pc.IsStrOut || pc.OrigCType == "const char *":
+		p.Kind, p.GoType = model.String, "string"
+	case pc.OrigCType == "char *":
+		p.Kind = model.Bytes
+	case pc.IsBoolOut || pc.OrigCType == "MSKbooleant":
+		p.Kind, p.GoType = model.Bool, "bool"
+	case pc.IsOutputSlice() || (pc.IsPointer && !pc.IsOutput):
+		p.Kind = model.Slice
+	default:
+		p.Kind = model.Scalar
+	}
+
+	return p
+}
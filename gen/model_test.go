@@ -0,0 +1,173 @@
+package gen
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fardream/gen-gmsk/model"
+	"github.com/fardream/gen-gmsk/mskh"
+)
+
+// memWriter keeps the emitted files in memory.
+type memWriter map[string][]byte
+
+func (m memWriter) WriteFile(name string, content []byte) error {
+	m[name] = content
+	return nil
+}
+
+// parsedGmsk is the declarations in the emitted go files of gmsk, built with cgo.
+type parsedGmsk struct {
+	funcs  map[string]*ast.FuncDecl // keyed by Receiver.Name, or Name for package level functions
+	consts map[string]string        // constant name -> value
+	types  map[string]string        // type name -> underlying type, prefixed with = for aliases
+}
+
+func parseEmitted(t *testing.T, files memWriter) *parsedGmsk {
+	t.Helper()
+	r := &parsedGmsk{funcs: make(map[string]*ast.FuncDecl), consts: make(map[string]string), types: make(map[string]string)}
+	fset := token.NewFileSet()
+	for name, content := range files {
+		if filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_nocgo.go") || filepath.Dir(name) != "." {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, content, 0)
+		if err != nil {
+			t.Fatalf("failed to parse emitted %s: %s", name, err)
+		}
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				key := d.Name.Name
+				if d.Recv != nil {
+					key = strings.TrimPrefix(types.ExprString(d.Recv.List[0].Type), "*") + "." + key
+				}
+				r.funcs[key] = d
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch s := spec.(type) {
+					case *ast.TypeSpec:
+						underlying := types.ExprString(s.Type)
+						if s.Assign.IsValid() {
+							underlying = "=" + underlying
+						}
+						r.types[s.Name.Name] = underlying
+					case *ast.ValueSpec:
+						if d.Tok == token.CONST && len(s.Values) == len(s.Names) {
+							for i, n := range s.Names {
+								r.consts[n.Name] = types.ExprString(s.Values[i])
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return r
+}
+
+// fieldsOf lists the names and types of the fields, one for each name.
+func fieldsOf(l *ast.FieldList) (names []string, goTypes []string) {
+	if l == nil {
+		return nil, nil
+	}
+	for _, f := range l.List {
+		for _, n := range f.Names {
+			names = append(names, n.Name)
+			goTypes = append(goTypes, types.ExprString(f.Type))
+		}
+	}
+
+	return names, goTypes
+}
+
+// modelGoType is the go type of the parameter in the go function.
+func modelGoType(p *model.Param) string {
+	switch p.Kind {
+	case model.Slice, model.AllocSlice:
+		return "[]" + p.GoType
+	case model.StringArray:
+		return "[]string"
+	case model.Bytes:
+		return "*byte"
+	default:
+		return p.GoType
+	}
+}
+
+// TestModelMatchesEmitted checks the functions and enums in the model are the ones emitted by the templates.
+func TestModelMatchesEmitted(t *testing.T) {
+	h, err := mskh.Parse(filepath.Join(gmskTestDir, "mosek.h"), &mskh.ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := NewOutputConfig()
+	api, err := Normalize(h, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(memWriter)
+	if err := NewGmskEmitter(h, config).Emit(files); err != nil {
+		t.Fatal(err)
+	}
+	emitted := parseEmitted(t, files)
+
+	for _, f := range api.Functions {
+		key := f.GoName
+		if f.Receiver != "" {
+			key = f.Receiver + "." + key
+		}
+		decl, found := emitted.funcs[key]
+		if !found {
+			t.Errorf("%s of %s is not emitted", key, f.CName)
+			continue
+		}
+
+		var inNames, inTypes, outNames, outTypes []string
+		for _, p := range f.Params {
+			if p.SizeFunc != "" {
+				continue
+			}
+			if p.Direction == model.Out {
+				outNames, outTypes = append(outNames, p.GoName), append(outTypes, modelGoType(p))
+			} else {
+				inNames, inTypes = append(inNames, p.GoName), append(inTypes, modelGoType(p))
+			}
+		}
+		gotInNames, gotInTypes := fieldsOf(decl.Type.Params)
+		if strings.Join(gotInNames, ",") != strings.Join(inNames, ",") || strings.Join(gotInTypes, ",") != strings.Join(inTypes, ",") {
+			t.Errorf("%s: emitted parameters %v %v, model %v %v", key, gotInNames, gotInTypes, inNames, inTypes)
+		}
+		gotOutNames, gotOutTypes := fieldsOf(decl.Type.Results)
+		if len(outNames) > 0 {
+			// the last result is the error.
+			gotOutNames, gotOutTypes = gotOutNames[:len(gotOutNames)-1], gotOutTypes[:len(gotOutTypes)-1]
+		} else {
+			gotOutNames, gotOutTypes = nil, nil
+		}
+		if strings.Join(gotOutNames, ",") != strings.Join(outNames, ",") || strings.Join(gotOutTypes, ",") != strings.Join(outTypes, ",") {
+			t.Errorf("%s: emitted results %v %v, model %v %v", key, gotOutNames, gotOutTypes, outNames, outTypes)
+		}
+	}
+
+	for _, e := range api.Enums {
+		underlying := e.IntegerType
+		if e.IsAlias {
+			underlying = "=" + underlying
+		}
+		if got, found := emitted.types[e.GoName]; !found || got != underlying {
+			t.Errorf("enum %s is emitted as %q, model %q", e.GoName, got, underlying)
+		}
+		for _, c := range e.Constants {
+			if got, found := emitted.consts[c.GoName]; !found || got != c.Value {
+				t.Errorf("constant %s of %s is emitted as %q, model %q", c.GoName, e.GoName, got, c.Value)
+			}
+		}
+	}
+}
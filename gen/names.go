@@ -0,0 +1,112 @@
+package gen
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fardream/gen-gmsk/mskh"
+)
+
+var rustExternFuncRe = regexp.MustCompile(`(?m)^\s*fn (MSK_\w+)\((\w*)`)
+
+// rustReceiverTypes are the C types of the first parameters of env and task functions in the rust binding.
+var rustReceiverTypes = map[string]string{
+	"env_":  "MSKenv_t",
+	"task_": "MSKtask_t",
+}
+
+// HeaderFromRustLib builds a [mskh.MosekH] with only function names, taken from the extern block
+// of mosek rust binding. This is used when mosek.h is not available.
+// The env or task parameter is kept so the functions are in the same scope as from mosek.h.
+func HeaderFromRustLib(fileName string) (*mskh.MosekH, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	h := mskh.NewMosekH()
+	seen := make(map[string]struct{})
+	for _, m := range rustExternFuncRe.FindAllSubmatch(content, -1) {
+		name := string(m[1])
+		if _, found := seen[name]; found {
+			continue
+		}
+		seen[name] = struct{}{}
+		f := &mskh.MskFunction{Name: name}
+		if t, found := rustReceiverTypes[string(m[2])]; found {
+			f.Parameters = []mskh.ParamDecl{{Name: strings.TrimSuffix(string(m[2]), "_"), Type: t}}
+		}
+		h.Functions = append(h.Functions, f)
+	}
+
+	return h, nil
+}
+
+// urlReceiverTypes are the C types of the first parameters of env and task functions, by the anchor of their documentation url.
+var urlReceiverTypes = map[string]string{
+	"#mosek.env.":  "MSKenv_t",
+	"#mosek.task.": "MSKtask_t",
+}
+
+// HeaderFromKnownFunctions builds a [mskh.MosekH] with the names of all the functions known without mosek.h,
+// which are the functions of [HeaderFromRustLib], followed by the other functions in config.yml, deprecated.yml and urls.yml.
+// The env or task parameter of the other functions is taken from the anchor of their documentation url.
+// config must not be normalized, so its functions are only the ones in config.yml.
+func HeaderFromKnownFunctions(rustLib string, config *OutputConfig) (*mskh.MosekH, error) {
+	h, err := HeaderFromRustLib(rustLib)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]struct{})
+	for _, f := range h.Functions {
+		known[f.Name] = struct{}{}
+	}
+	var others []string
+	for _, names := range [][]string{keys(config.Funcs), keys(config.Deprecated), keys(config.Urls)} {
+		for _, name := range names {
+			if _, found := known[name]; !found {
+				known[name] = struct{}{}
+				others = append(others, name)
+			}
+		}
+	}
+	slices.Sort(others)
+	for _, name := range others {
+		f := &mskh.MskFunction{Name: name}
+		for anchor, t := range urlReceiverTypes {
+			if strings.Contains(config.Urls[name], anchor) {
+				f.Parameters = []mskh.ParamDecl{{Name: strings.TrimSuffix(strings.TrimPrefix(t, "MSK"), "_t"), Type: t}}
+			}
+		}
+		h.Functions = append(h.Functions, f)
+	}
+
+	return h, nil
+}
+
+// WriteNameTable writes the C name to go name table of all the functions, config must be normalized.
+func WriteNameTable(h *mskh.MosekH, config *OutputConfig, out io.Writer) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "C NAME\tGO NAME\tSOURCE\tRULES NAME\tRULES")
+	for _, f := range h.Functions {
+		fc, found := config.Funcs[f.Name]
+		if !found {
+			return fmt.Errorf("cannot find %s in function configs", f.Name)
+		}
+		goName, source := fc.GoName, fc.nameSource
+		if fc.Skip {
+			goName, source = "-", "skip"
+		}
+		rule := fc.derivation.Rule()
+		if rule == "" {
+			rule = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", f.Name, goName, source, fc.derivation.Name, rule)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,178 @@
+package gen
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type pair struct {
+	MskName string `json:"msk_name"`
+	GoName  string `json:"go_name"`
+}
+
+// substitution replaces a prefix or a suffix with GoName.
+type substitution struct {
+	Prefix string `json:"prefix"`
+	Suffix string `json:"suffix"`
+	GoName string `json:"go_name"`
+}
+
+func (s *substitution) apply(v string) string {
+	switch {
+	case s.Prefix != "":
+		_, v = replacePrefix(v, s.Prefix, s.GoName)
+	case s.Suffix != "":
+		_, v = replaceSuffix(v, s.Suffix, s.GoName)
+	}
+	return v
+}
+
+func (s *substitution) String() string {
+	if s.Prefix != "" {
+		return fmt.Sprintf("%s*", s.Prefix)
+	}
+	return fmt.Sprintf("*%s", s.Suffix)
+}
+
+// scopedMidRule names the mid part of functions with the given action and suffix.
+type scopedMidRule struct {
+	Action        string         `json:"action"`
+	Suffix        string         `json:"suffix"`
+	Substitutions []substitution `json:"substitutions"`
+}
+
+// regexNameRule gives the full go name of the functions matching the pattern.
+type regexNameRule struct {
+	Pattern string `json:"pattern"`
+	GoName  string `json:"go_name"`
+
+	re *regexp.Regexp
+}
+
+// namingRules turn the C function names into go names.
+type namingRules struct {
+	Actions    []pair           `json:"actions"`
+	Suffixes   []pair           `json:"suffixes"`
+	Mids       []pair           `json:"mids"`
+	ScopedMids []*scopedMidRule `json:"scoped_mids"`
+	Regexes    []*regexNameRule `json:"regexes"`
+}
+
+func (r *namingRules) compile() error {
+	for _, v := range r.Regexes {
+		re, err := regexp.Compile(v.Pattern)
+		if err != nil {
+			return fmt.Errorf("failed to compile naming regex %s: %w", v.Pattern, err)
+		}
+		v.re = re
+	}
+
+	return nil
+}
+
+// nameDerivation is the result of applying naming rules to a C function name.
+type nameDerivation struct {
+	Action string
+	Mid    string
+	Suffix string
+	Name   string
+	Rules  []string // rules used, in the order of application
+}
+
+func (d *nameDerivation) Rule() string {
+	return strings.Join(d.Rules, " ")
+}
+
+func (r *namingRules) getFuncAction(s string) (string, string, string) {
+	for _, a := range r.Actions {
+		if strings.HasPrefix(s, a.MskName) {
+			return strings.TrimPrefix(s, a.MskName), a.GoName, a.MskName
+		}
+	}
+
+	return s, "", ""
+}
+
+func (r *namingRules) getFunctionSuffix(s string) (string, string, string) {
+	for _, a := range r.Suffixes {
+		if strings.HasSuffix(s, a.MskName) {
+			return strings.TrimSuffix(s, a.MskName), a.GoName, a.MskName
+		}
+	}
+
+	return s, "", ""
+}
+
+// splitFuncName splits the function name into action, the unprocessed mid part, and suffix.
+func (r *namingRules) splitFuncName(s string) (d *nameDerivation, mid string) {
+	d = &nameDerivation{}
+	after, action, matched := r.getFuncAction(GetGoName(s))
+	d.Action = action
+	if matched != "" {
+		d.Rules = append(d.Rules, fmt.Sprintf("action:%s", matched))
+	}
+	mid, suffix, matched := r.getFunctionSuffix(after)
+	d.Suffix = suffix
+	if matched != "" {
+		d.Rules = append(d.Rules, fmt.Sprintf("suffix:%s", matched))
+	}
+	return d, mid
+}
+
+func (r *namingRules) midName(d *nameDerivation, mid string) string {
+	for _, sm := range r.ScopedMids {
+		if sm.Action != d.Action || sm.Suffix != d.Suffix {
+			continue
+		}
+		s := mid
+		for _, v := range sm.Substitutions {
+			s = v.apply(s)
+		}
+		d.Rules = append(d.Rules, fmt.Sprintf("scoped_mid:%s/%s", sm.Action, sm.Suffix))
+		return upperCaseFirstLetter(s)
+	}
+
+	for _, v := range r.Mids {
+		t, p := replacePrefix(mid, v.MskName, v.GoName)
+		if t {
+			d.Rules = append(d.Rules, fmt.Sprintf("mid:%s", v.MskName))
+			return p
+		}
+	}
+
+	return upperCaseFirstLetter(mid)
+}
+
+// derive computes the canonical go name of a C function.
+func (r *namingRules) derive(cname string) *nameDerivation {
+	d, mid := r.splitFuncName(cname)
+	d.Mid = r.midName(d, mid)
+	d.Name = fmt.Sprintf("%s%s%s", d.Action, d.Mid, d.Suffix)
+
+	s := GetGoName(cname)
+	for _, v := range r.Regexes {
+		if m := v.re.FindStringSubmatchIndex(s); m != nil {
+			d.Name = string(v.re.ExpandString(nil, v.GoName, s, m))
+			d.Rules = []string{fmt.Sprintf("regex:%s", v.Pattern)}
+			break
+		}
+	}
+
+	return d
+}
+
+func replacePrefix(s, oldPrefix, newPrefix string) (bool, string) {
+	if strings.HasPrefix(s, oldPrefix) {
+		return true, fmt.Sprintf("%s%s", newPrefix,
+			upperCaseFirstLetter(strings.TrimPrefix(s, oldPrefix)))
+	}
+	return false, s
+}
+
+func replaceSuffix(s, oldSuffix, newSuffix string) (bool, string) {
+	if strings.HasSuffix(s, oldSuffix) {
+		return true, fmt.Sprintf("%s%s", strings.TrimSuffix(s, oldSuffix), newSuffix)
+	}
+	return false, s
+}
@@ -0,0 +1,77 @@
+package gen
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fardream/gen-gmsk/mskh"
+)
+
+// stubZeroValue is the zero value of the go type returned by the wrappers without an error.
+func stubZeroValue(goType string) string {
+	switch goType {
+	case "bool":
+		return "false"
+	case "string":
+		return `""`
+	default:
+		// the other go types of C return values are numbers and enums.
+		return "0"
+	}
+}
+
+// StubBody is the body of the !cgo stub of the function, returning ErrNoMosek.
+// Functions without an error to return return the zero value, so they can still be called by tests without cgo.
+func (t *FuncTmplInput) StubBody() string {
+	returnType := t.ReturnType()
+	switch {
+	case returnType == "":
+		return ""
+	case returnType == "error":
+		return "return ErrNoMosek"
+	case strings.HasPrefix(returnType, "("):
+		return fmt.Sprintf("%s = ErrNoMosek\n\treturn", t.ReturnValueName())
+	default:
+		return "return " + stubZeroValue(returnType)
+	}
+}
+
+// StubBehavior describes what the !cgo stub of the function does, for its doc.
+func (t *FuncTmplInput) StubBehavior() string {
+	returnType := t.ReturnType()
+	switch {
+	case returnType == "":
+		return "which does nothing"
+	case returnType == "error" || strings.HasPrefix(returnType, "("):
+		return "which always fails with [ErrNoMosek]"
+	default:
+		return fmt.Sprintf("which always returns %s since it has no error to return [ErrNoMosek] with", stubZeroValue(returnType))
+	}
+}
+
+// BuildFuncStubs generates the !cgo stubs of the functions of the type.
+func BuildFuncStubs(h *mskh.MosekH, config *OutputConfig, funcTypeFilter funcType, out io.Writer) error {
+	input, err := buildFuncFileInput(h, config, funcTypeFilter)
+	if err != nil {
+		return err
+	}
+	input.Desc = "stubs of function definitions without cgo"
+
+	return funcStubFileTmpl.Execute(out, input)
+}
+
+// StubOutputFile is the file of the !cgo stubs of the functions.
+func (t funcType) StubOutputFile() string {
+	return fmt.Sprintf("%s_nocgo.go", t.String())
+}
+
+// BuildNoMosek generates ErrNoMosek.
+func BuildNoMosek(h *mskh.MosekH, config *OutputConfig, out io.Writer) error {
+	return noCgoFileTmpl.ExecuteTemplate(out, "errors", config)
+}
+
+// BuildNoCgoTypes generates the !cgo placeholders of the types declared by the hand written code.
+func BuildNoCgoTypes(h *mskh.MosekH, config *OutputConfig, out io.Writer) error {
+	return noCgoFileTmpl.ExecuteTemplate(out, "types", config)
+}
@@ -1,13 +1,15 @@
-package main
+package gen
 
 import (
 	_ "embed"
 	"fmt"
 	"io"
 	"log"
+	"regexp"
 	"strings"
 	"text/template"
 
+	"github.com/fardream/gen-gmsk/mskh"
 	"github.com/goccy/go-yaml"
 )
 
@@ -26,22 +28,67 @@ var deprecatedStr []byte
 //go:embed enums.tmpl
 var enumTmpl string
 
+//go:embed typed_params.tmpl
+var typedParamTmpl string
+
+//go:embed params.tmpl
+var paramsTmpl string
+
+//go:embed parfile.tmpl
+var parFileTmplStr string
+
+//go:embed solver_info.tmpl
+var solverInfoTmpl string
+
+//go:embed solution.tmpl
+var solutionTmpl string
+
+//go:embed dlopen.tmpl
+var dlopenTmpl string
+
+//go:embed func_stub.tmpl
+var funcStubTmpl string
+
+//go:embed nocgo.tmpl
+var noCgoTmpl string
+
+//go:embed coverage.tmpl
+var coverageTmpl string
+
+//go:embed cgo_flags.tmpl
+var cgoFlagsTmpl string
+
+//go:embed version.tmpl
+var versionTmpl string
+
 type OutputConfig struct {
-	Enums           map[string]*enumConfig `json:"enums"`
-	PackageName     string                 `json:"package_name"`
-	TypeToGoType    map[string]string      `json:"type_to_go_type"`
-	Funcs           map[string]*FuncConfig `json:"funcs"`
-	Deprecated      map[string]struct{}    `json:"deprecated"`
-	Urls            map[string]string      `json:"urls"`
-	RustFuncs       []RustFunc             `json:"rust_funcs"`
-	RustEnums       map[string]RustEnum    `json:"rust_enums"`
-	mappedRustFuncs map[string]RustFunc    `json:"-"`
+	Enums                 map[string]*enumConfig `json:"enums"`
+	PackageName           string                 `json:"package_name"`
+	ParFilePackage        string                 `json:"par_file_package"`          // package and sub directory of the parameter file reader and writer
+	DlopenBuildTag        string                 `json:"dlopen_build_tag"`          // build tag of the backend loading libmosek at runtime, empty to disable
+	CheckVersionInMakeEnv bool                   `json:"check_version_in_make_env"` // default of CheckVersionInMakeEnv
+	TypeToGoType          map[string]string      `json:"type_to_go_type"`
+	Funcs                 map[string]*FuncConfig `json:"funcs"`
+	Deprecated            map[string]struct{}    `json:"deprecated"`
+	Urls                  map[string]string      `json:"urls"`
+	RustFuncs             []RustFunc             `json:"rust_funcs"`
+	RustEnums             map[string]RustEnum    `json:"rust_enums"`
+	ReservedNames         []string               `json:"reserved_names"` // identifiers declared by the hand written code of the package
+	Naming                *namingRules           `json:"naming"`
+	ParamRenames          map[string]string      `json:"param_renames"`      // C parameter name -> go parameter name
+	ParamValueEnums       map[string]string      `json:"param_value_enums"`  // integer parameter -> C enum of its values
+	ParamDocRules         []*paramDocRule        `json:"param_doc_rules"`    // find C enum of integer parameter values by documentation
+	InfoFieldRenames      map[string]string      `json:"info_field_renames"` // information item -> snake case json key and field name in SolverInfo
+	mappedRustFuncs       map[string]RustFunc    `json:"-"`
+	params                []*paramInfo           `json:"-"` // metadata of all the parameters, built by normalize
 }
 
-func newOutputConfig() *OutputConfig {
+// NewOutputConfig loads the embedded config.yml, urls, deprecation list and the data from the rust binding.
+func NewOutputConfig() *OutputConfig {
 	r := &OutputConfig{
-		Enums:       make(map[string]*enumConfig),
-		PackageName: "gmsk",
+		Enums:          make(map[string]*enumConfig),
+		PackageName:    "gmsk",
+		ParFilePackage: "parfile",
 		TypeToGoType: map[string]string{
 			"int32_t":      "int32",
 			"int64_t":      "int64",
@@ -76,6 +123,21 @@ func newOutputConfig() *OutputConfig {
 		log.Panic(err)
 	}
 
+	if r.Naming == nil {
+		r.Naming = &namingRules{}
+	}
+	if err := r.Naming.compile(); err != nil {
+		log.Panic(err)
+	}
+
+	for _, v := range r.ParamDocRules {
+		re, err := regexp.Compile(v.Pattern)
+		if err != nil {
+			log.Panicf("failed to compile param doc rule %s: %s", v.Pattern, err.Error())
+		}
+		v.re = re
+	}
+
 	for _, f := range r.RustFuncs {
 		mskname := fmt.Sprintf("MSK_%s", strings.ReplaceAll(f.Name, "_", ""))
 		r.mappedRustFuncs[mskname] = f
@@ -149,7 +211,7 @@ func stripCTypePrefix(s string) string {
 	return s
 }
 
-func normalize(h *MosekH, config *OutputConfig) error {
+func normalize(h *mskh.MosekH, config *OutputConfig) error {
 	if config.Enums == nil {
 		config.Enums = make(map[string]*enumConfig)
 	}
@@ -225,6 +287,12 @@ func normalize(h *MosekH, config *OutputConfig) error {
 	for _, f := range h.Functions {
 		normalizeFunction(f, config)
 	}
+	// length functions are only known after all the functions are normalized.
+	for _, f := range h.Functions {
+		pairStrLen(f, config)
+	}
+
+	config.params = buildParamTable(h, config)
 
 	return nil
 }
@@ -247,7 +315,16 @@ func (fi *funcFileTmplInput) ExtraStdPkgs() []string {
 	return keys(pkgs)
 }
 
-func BuildFuncs(h *MosekH, config *OutputConfig, funcTypeFilter funcType, out io.Writer) error {
+func BuildFuncs(h *mskh.MosekH, config *OutputConfig, funcTypeFilter funcType, out io.Writer) error {
+	input, err := buildFuncFileInput(h, config, funcTypeFilter)
+	if err != nil {
+		return err
+	}
+
+	return funcFileTmpl.Execute(out, input)
+}
+
+func buildFuncFileInput(h *mskh.MosekH, config *OutputConfig, funcTypeFilter funcType) (*funcFileTmplInput, error) {
 	input := &funcFileTmplInput{
 		Desc:         "function deinitions",
 		OutputConfig: config,
@@ -256,7 +333,7 @@ func BuildFuncs(h *MosekH, config *OutputConfig, funcTypeFilter funcType, out io
 	for _, f := range h.Functions {
 		fc, ok := config.Funcs[f.Name]
 		if !ok {
-			return fmt.Errorf("cannot find %s in function configs", f.Name)
+			return nil, fmt.Errorf("cannot find %s in function configs", f.Name)
 		}
 		if fc.Skip || fc.FuncType != funcTypeFilter {
 			continue
@@ -268,12 +345,23 @@ func BuildFuncs(h *MosekH, config *OutputConfig, funcTypeFilter funcType, out io
 		})
 	}
 
-	return funcFileTmpl.Execute(out, &input)
+	return input, nil
 }
 
 var (
-	funcFileTmpl *template.Template
-	enumFileTmpl *template.Template
+	funcFileTmpl       *template.Template
+	enumFileTmpl       *template.Template
+	typedParamFileTmpl *template.Template
+	paramsFileTmpl     *template.Template
+	parFileTmpl        *template.Template
+	solverInfoFileTmpl *template.Template
+	solutionFileTmpl   *template.Template
+	dlopenFileTmpl     *template.Template
+	funcStubFileTmpl   *template.Template
+	noCgoFileTmpl      *template.Template
+	coverageFileTmpl   *template.Template
+	cgoFlagsFileTmpl   *template.Template
+	versionFileTmpl    *template.Template
 )
 
 func init() {
@@ -286,4 +374,48 @@ func init() {
 	if err != nil {
 		log.Panic(err)
 	}
+	typedParamFileTmpl, err = template.New("typed-param-tmpl").Parse(typedParamTmpl)
+	if err != nil {
+		log.Panic(err)
+	}
+	paramsFileTmpl, err = template.New("params-tmpl").Parse(paramsTmpl)
+	if err != nil {
+		log.Panic(err)
+	}
+	parFileTmpl, err = template.New("parfile-tmpl").Parse(parFileTmplStr)
+	if err != nil {
+		log.Panic(err)
+	}
+	solverInfoFileTmpl, err = template.New("solver-info-tmpl").Parse(solverInfoTmpl)
+	if err != nil {
+		log.Panic(err)
+	}
+	solutionFileTmpl, err = template.New("solution-tmpl").Parse(solutionTmpl)
+	if err != nil {
+		log.Panic(err)
+	}
+	dlopenFileTmpl, err = template.New("dlopen-tmpl").Parse(dlopenTmpl)
+	if err != nil {
+		log.Panic(err)
+	}
+	funcStubFileTmpl, err = template.New("func-stub-tmpl").Parse(funcStubTmpl)
+	if err != nil {
+		log.Panic(err)
+	}
+	noCgoFileTmpl, err = template.New("nocgo-tmpl").Parse(noCgoTmpl)
+	if err != nil {
+		log.Panic(err)
+	}
+	coverageFileTmpl, err = template.New("coverage-tmpl").Parse(coverageTmpl)
+	if err != nil {
+		log.Panic(err)
+	}
+	cgoFlagsFileTmpl, err = template.New("cgo-flags-tmpl").Parse(cgoFlagsTmpl)
+	if err != nil {
+		log.Panic(err)
+	}
+	versionFileTmpl, err = template.New("version-tmpl").Parse(versionTmpl)
+	if err != nil {
+		log.Panic(err)
+	}
 }
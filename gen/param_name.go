@@ -0,0 +1,52 @@
+package gen
+
+import (
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+)
+
+// templateLocals are the names used by func.tmpl for receivers, locals, and packages.
+var templateLocals = []string{"r", "rescode", "res", "task", "env", "C", "unsafe", "err", "dst", "appendLen", "slices"}
+
+// snakeToLowerCamel converts a snake case C name to lower camel case.
+func snakeToLowerCamel(s string) string {
+	parts := strings.Split(strings.Trim(s, "_"), "_")
+	b := strings.Builder{}
+	for i, v := range parts {
+		if i == 0 {
+			b.WriteString(lowerCaseFirstLetter(v))
+		} else {
+			b.WriteString(upperCaseFirstLetter(v))
+		}
+	}
+
+	return b.String()
+}
+
+// isUnsafeParamName checks if the name is a go keyword, a predeclared identifier, a name used by the template,
+// or one of the reserved names in the package.
+func isUnsafeParamName(name string, config *OutputConfig) bool {
+	return token.IsKeyword(name) ||
+		types.Universe.Lookup(name) != nil ||
+		slices.Contains(templateLocals, name) ||
+		slices.Contains(config.ReservedNames, name)
+}
+
+// goParamName maps the C parameter name to a go parameter name that is safe to use in generated functions.
+func goParamName(cname string, config *OutputConfig) string {
+	if v, found := config.ParamRenames[cname]; found {
+		return v
+	}
+
+	name := snakeToLowerCamel(cname)
+	if v, found := config.ParamRenames[name]; found {
+		return v
+	}
+	if isUnsafeParamName(name, config) {
+		return name + "Param"
+	}
+
+	return name
+}
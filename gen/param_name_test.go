@@ -0,0 +1,23 @@
+package gen
+
+import "testing"
+
+func TestGoParamName(t *testing.T) {
+	config := NewOutputConfig()
+	for cname, expected := range map[string]string{
+		"numvar":     "numvar",
+		"whichsol_":  "whichsol",
+		"sub_j":      "subJ",
+		"type":       "typeParam",
+		"len":        "length", // param_renames in config.yml
+		"task":       "taskParam",
+		"dst":        "dstParam",
+		"err":        "errParam",
+		"append_len": "appendLenParam",
+		"slices":     "slicesParam",
+	} {
+		if got := goParamName(cname, config); got != expected {
+			t.Errorf("goParamName(%q) = %q, expected %q", cname, got, expected)
+		}
+	}
+}
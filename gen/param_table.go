@@ -0,0 +1,228 @@
+package gen
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/fardream/gen-gmsk/mskh"
+)
+
+// paramKind is the kind of values of a mosek parameter.
+type paramKind uint
+
+const (
+	paramKind_INT    paramKind = iota // integer parameter, MSKiparam_enum
+	paramKind_DOUBLE                  // double parameter, MSKdparam_enum
+	paramKind_STRING                  // string parameter, MSKsparam_enum
+)
+
+// paramEnumInfo is a C enum listing the parameters of a kind.
+type paramEnumInfo struct {
+	kind     paramKind
+	enumName string
+	prefix   string
+}
+
+// paramEnums are the C enums listing the parameters of each kind.
+var paramEnums = []paramEnumInfo{
+	{kind: paramKind_INT, enumName: "MSKiparam_enum", prefix: "MSK_IPAR_"},
+	{kind: paramKind_DOUBLE, enumName: "MSKdparam_enum", prefix: "MSK_DPAR_"},
+	{kind: paramKind_STRING, enumName: "MSKsparam_enum", prefix: "MSK_SPAR_"},
+}
+
+// paramDocRule maps integer parameters to the enum of their values by their documentation.
+type paramDocRule struct {
+	Pattern string `json:"pattern"`
+	Enum    string `json:"enum"`
+
+	re *regexp.Regexp
+}
+
+// paramInfo is the metadata of a mosek parameter.
+type paramInfo struct {
+	CName       string // C name, like MSK_IPAR_OPTIMIZER
+	GoName      string // go constant name, like IPAR_OPTIMIZER
+	Kind        paramKind
+	ValueEnum   string // C enum of the values, only for integer parameters
+	ValueGoType string // go type of the values
+	Comment     string
+	MethodName  string // name used in the typed setter and getter, and the field of Params, like Optimizer
+	Tag         string // json and yaml key in Params, like optimizer
+}
+
+func (p *paramInfo) IsInt() bool {
+	return p.Kind == paramKind_INT
+}
+
+func (p *paramInfo) IsDouble() bool {
+	return p.Kind == paramKind_DOUBLE
+}
+
+func (p *paramInfo) IsString() bool {
+	return p.Kind == paramKind_STRING
+}
+
+// valueEnum finds the enum for the values of the integer parameter, from config first then documentation.
+func valueEnum(cname, comment string, config *OutputConfig) string {
+	if v, found := config.ParamValueEnums[cname]; found {
+		return v
+	}
+	for _, rule := range config.ParamDocRules {
+		if rule.re.MatchString(comment) {
+			return rule.Enum
+		}
+	}
+
+	return ""
+}
+
+// buildParamTable builds the metadata of all the parameters, it is called once at the end of normalize into config.params.
+func buildParamTable(h *mskh.MosekH, config *OutputConfig) []*paramInfo {
+	var r []*paramInfo
+	for _, pe := range paramEnums {
+		e, found := h.Enums[pe.enumName]
+		if !found {
+			continue
+		}
+		ec, found := config.Enums[pe.enumName]
+		if !found || ec.Skip {
+			continue
+		}
+
+		for _, ev := range e.Values {
+			p := &paramInfo{
+				CName:      ev.Name,
+				GoName:     ec.ConstantGoName(ev.Name, enumConstantPrefix),
+				Kind:       pe.kind,
+				Comment:    ec.ConstantComments[ev.Name],
+				MethodName: snakeToCamel(strings.ToLower(strings.TrimPrefix(ev.Name, pe.prefix))),
+				Tag:        strings.ToLower(strings.TrimPrefix(ev.Name, pe.prefix)),
+			}
+			switch pe.kind {
+			case paramKind_INT:
+				p.ValueGoType = "int32"
+				p.ValueEnum = valueEnum(ev.Name, p.Comment, config)
+				if p.ValueEnum == "" {
+					break
+				}
+				vec, found := config.Enums[p.ValueEnum]
+				if _, inHeader := h.Enums[p.ValueEnum]; !found || !inHeader || vec.Skip {
+					log.Printf("cannot find enum %s for the values of %s", p.ValueEnum, ev.Name)
+					p.ValueEnum = ""
+					break
+				}
+				p.ValueGoType = vec.GoName
+			case paramKind_DOUBLE:
+				p.ValueGoType = "float64"
+			case paramKind_STRING:
+				p.ValueGoType = "string"
+			}
+			r = append(r, p)
+		}
+	}
+
+	return r
+}
+
+type typedParamFileTmplInput struct {
+	*OutputConfig
+	Params []*paramInfo
+}
+
+// BuildTypedParams writes the typed setters and getters for integer parameters whose values are enums.
+func BuildTypedParams(h *mskh.MosekH, config *OutputConfig, out io.Writer) error {
+	input := &typedParamFileTmplInput{OutputConfig: config}
+	for _, p := range config.params {
+		if p.Kind == paramKind_INT && p.ValueEnum != "" {
+			input.Params = append(input.Params, p)
+		}
+	}
+
+	if err := typedParamFileTmpl.Execute(out, input); err != nil {
+		return fmt.Errorf("failed to generate typed parameters: %w", err)
+	}
+	return nil
+}
+
+// BuildTypedParamStubs writes the !cgo twin of the typed setters and getters, which always fail with [ErrNoMosek].
+func BuildTypedParamStubs(h *mskh.MosekH, config *OutputConfig, out io.Writer) error {
+	input := &typedParamFileTmplInput{OutputConfig: config}
+	for _, p := range config.params {
+		if p.Kind == paramKind_INT && p.ValueEnum != "" {
+			input.Params = append(input.Params, p)
+		}
+	}
+
+	if err := typedParamFileTmpl.ExecuteTemplate(out, "nocgo", input); err != nil {
+		return fmt.Errorf("failed to generate typed parameter stubs: %w", err)
+	}
+	return nil
+}
+
+type paramsFileTmplInput struct {
+	*OutputConfig
+	Params []*paramInfo
+}
+
+// BuildParams writes the Params struct with all the parameters, and the methods to apply and read them.
+func BuildParams(h *mskh.MosekH, config *OutputConfig, out io.Writer) error {
+	input := &paramsFileTmplInput{OutputConfig: config, Params: config.params}
+
+	if err := paramsFileTmpl.Execute(out, input); err != nil {
+		return fmt.Errorf("failed to generate params: %w", err)
+	}
+	return nil
+}
+
+// BuildParamsStubs writes the !cgo twin of the Params struct and its methods, which always fail with [ErrNoMosek].
+func BuildParamsStubs(h *mskh.MosekH, config *OutputConfig, out io.Writer) error {
+	input := &paramsFileTmplInput{OutputConfig: config, Params: config.params}
+
+	if err := paramsFileTmpl.ExecuteTemplate(out, "nocgo", input); err != nil {
+		return fmt.Errorf("failed to generate params stubs: %w", err)
+	}
+	return nil
+}
+
+type parFileEnum struct {
+	*mskh.MskEnum
+	VarName string
+}
+
+type parFileTmplInput struct {
+	*OutputConfig
+	Params   []*paramInfo
+	Enums    []*parFileEnum
+	EnumVars map[string]string // C enum name -> variable name of the symbols
+}
+
+// BuildParFile writes the pure go package reading and writing mosek parameter files.
+// Integer parameters take the symbolic values of their own enums, or plain integers if their values are not enums.
+func BuildParFile(h *mskh.MosekH, config *OutputConfig, out io.Writer) error {
+	input := &parFileTmplInput{
+		OutputConfig: config,
+		Params:       config.params,
+		EnumVars:     make(map[string]string),
+	}
+	for _, enumName := range h.EnumList {
+		if !slices.ContainsFunc(input.Params, func(p *paramInfo) bool { return p.ValueEnum == enumName }) {
+			continue
+		}
+		e, found := h.Enums[enumName]
+		if !found {
+			continue
+		}
+		v := &parFileEnum{MskEnum: e, VarName: fmt.Sprintf("_%s_symbols", enumName)}
+		input.Enums = append(input.Enums, v)
+		input.EnumVars[enumName] = v.VarName
+	}
+
+	if err := parFileTmpl.Execute(out, input); err != nil {
+		return fmt.Errorf("failed to generate par file package: %w", err)
+	}
+	return nil
+}
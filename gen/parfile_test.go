@@ -0,0 +1,71 @@
+package gen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/fardream/gen-gmsk/mskh"
+)
+
+// parFileTestHeader has the parameters and enums used by testdata/parfile,
+// with the values in mosek.h which the documentation from the rust binding is matched by.
+func parFileTestHeader() *mskh.MosekH {
+	h := mskh.NewMosekH()
+	for _, e := range []*mskh.MskEnum{
+		(&mskh.MskEnum{Name: "MSKiparam_enum"}).AddValue("MSK_IPAR_LOG", "34").AddValue("MSK_IPAR_OPTIMIZER", "110"),
+		(&mskh.MskEnum{Name: "MSKdparam_enum"}).AddValue("MSK_DPAR_OPTIMIZER_MAX_TIME", "50"),
+		(&mskh.MskEnum{Name: "MSKsparam_enum"}).AddValue("MSK_SPAR_PARAM_READ_FILE_NAME", "7"),
+		(&mskh.MskEnum{Name: "MSKonoffkey_enum"}).AddValue("MSK_OFF", "0").AddValue("MSK_ON", "1"),
+		(&mskh.MskEnum{Name: "MSKoptimizertype_enum"}).AddValue("MSK_OPTIMIZER_CONIC", "0").AddValue("MSK_OPTIMIZER_INTPNT", "2"),
+	} {
+		h.Enums[e.Name] = e
+		h.EnumList = append(h.EnumList, e.Name)
+	}
+
+	return h
+}
+
+// TestParFile runs the tests in testdata/parfile against the generated parfile package.
+func TestParFile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test on the generated package")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go is not found")
+	}
+
+	h, config := parFileTestHeader(), NewOutputConfig()
+	if err := normalize(h, config); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	out, err := os.Create(filepath.Join(dir, "parfile.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+	if err := BuildParFile(h, config, out); err != nil {
+		t.Fatal(err)
+	}
+	testFile, err := os.ReadFile(filepath.Join("testdata", "parfile", "parfile_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string][]byte{
+		"go.mod":          []byte("module example.com/parfile\n\ngo 1.21\n"),
+		"parfile_test.go": testFile,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := exec.Command(goBin, "test", ".")
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go test of the generated parfile package failed: %s\n%s", err, output)
+	}
+}
@@ -0,0 +1,85 @@
+package gen
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fardream/gen-gmsk/mskh"
+)
+
+// solutionArray is an array of the solution read by MSK_getsolution.
+type solutionArray struct {
+	CName   string // parameter name of MSK_getsolution
+	CType   string
+	GoType  string
+	Dim     string // local variable of the size in Task.Solution
+	Comment string
+}
+
+func (a *solutionArray) FieldName() string {
+	return upperCaseFirstLetter(a.CName)
+}
+
+func (a *solutionArray) Flag() string {
+	return "SOLUTION_" + strings.ToUpper(a.CName)
+}
+
+// solutionArrays are the arrays of MSK_getsolution in the order of its parameters.
+// skn is always NULL since it is for the deprecated conic constraints.
+var solutionArrays = []*solutionArray{
+	{CName: "skc", CType: "MSKstakeye", GoType: "StaKey", Dim: "numcon", Comment: "status keys of the constraints"},
+	{CName: "skx", CType: "MSKstakeye", GoType: "StaKey", Dim: "numvar", Comment: "status keys of the variables"},
+	{CName: "xc", CType: "MSKrealt", GoType: "float64", Dim: "numcon", Comment: "primal values of the constraints"},
+	{CName: "xx", CType: "MSKrealt", GoType: "float64", Dim: "numvar", Comment: "primal values of the variables"},
+	{CName: "y", CType: "MSKrealt", GoType: "float64", Dim: "numcon", Comment: "dual values of the constraints, y = slc - suc"},
+	{CName: "slc", CType: "MSKrealt", GoType: "float64", Dim: "numcon", Comment: "dual values of the constraint lower bounds"},
+	{CName: "suc", CType: "MSKrealt", GoType: "float64", Dim: "numcon", Comment: "dual values of the constraint upper bounds"},
+	{CName: "slx", CType: "MSKrealt", GoType: "float64", Dim: "numvar", Comment: "dual values of the variable lower bounds"},
+	{CName: "sux", CType: "MSKrealt", GoType: "float64", Dim: "numvar", Comment: "dual values of the variable upper bounds"},
+	{CName: "snx", CType: "MSKrealt", GoType: "float64", Dim: "numvar", Comment: "dual values of the variables in conic constraints"},
+}
+
+// solutionFuncs are the C functions used by Task.Solution.
+var solutionFuncs = []string{
+	"MSK_solutiondef",
+	"MSK_getsolution",
+	"MSK_getnumcon",
+	"MSK_getnumvar",
+	"MSK_getnumbarvar",
+	"MSK_getlenbarvarj",
+	"MSK_getbarxj",
+	"MSK_getbarsj",
+	"MSK_getaccntot",
+	"MSK_getaccdotys",
+}
+
+type solutionTmplInput struct {
+	*OutputConfig
+	Arrays []*solutionArray
+}
+
+// BuildSolution writes the Solution struct and the method to read all or some of the solution arrays.
+func BuildSolution(h *mskh.MosekH, config *OutputConfig, out io.Writer) error {
+	for _, name := range solutionFuncs {
+		if !h.HasFunction(name) {
+			return fmt.Errorf("cannot find %s for Task.Solution", name)
+		}
+	}
+	input := &solutionTmplInput{OutputConfig: config, Arrays: solutionArrays}
+
+	if err := solutionFileTmpl.Execute(out, input); err != nil {
+		return fmt.Errorf("failed to generate solution: %w", err)
+	}
+	return nil
+}
+
+// BuildSolutionStubs writes the !cgo twin of the Solution struct and the method, which always fails with [ErrNoMosek].
+func BuildSolutionStubs(h *mskh.MosekH, config *OutputConfig, out io.Writer) error {
+	input := &solutionTmplInput{OutputConfig: config, Arrays: solutionArrays}
+
+	if err := solutionFileTmpl.ExecuteTemplate(out, "nocgo", input); err != nil {
+		return fmt.Errorf("failed to generate solution stubs: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package gen
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fardream/gen-gmsk/mskh"
+)
+
+// infoKinds are the C enums of the information items, with the C function and go type to read them.
+var infoKinds = []struct {
+	enumName string
+	prefix   string
+	cFunc    string
+	cType    string
+	goType   string
+}{
+	{enumName: "MSKdinfitem_enum", prefix: "MSK_DINF_", cFunc: "MSK_getdouinf", cType: "MSKrealt", goType: "float64"},
+	{enumName: "MSKiinfitem_enum", prefix: "MSK_IINF_", cFunc: "MSK_getintinf", cType: "MSKint32t", goType: "int32"},
+	{enumName: "MSKliinfitem_enum", prefix: "MSK_LIINF_", cFunc: "MSK_getlintinf", cType: "MSKint64t", goType: "int64"},
+}
+
+// infoItem is an information item, which is a field of SolverInfo.
+type infoItem struct {
+	CName     string // C name, like MSK_DINF_OPTIMIZER_TIME
+	GoName    string // go constant name, like DINF_OPTIMIZER_TIME
+	FieldName string // like OptimizerTime
+	Tag       string // json key, like optimizer_time
+	GoType    string
+	Comment   string
+}
+
+// infoGroup is the information items of the same kind, read by the same C function.
+type infoGroup struct {
+	EnumGoName string
+	CItemType  string // C typedef of the enum, like MSKdinfiteme
+	CFunc      string
+	CType      string
+	GoType     string
+	Items      []*infoItem
+}
+
+// buildInfoGroups builds the information items of all kinds, it must be called after normalize.
+func buildInfoGroups(h *mskh.MosekH, config *OutputConfig) []*infoGroup {
+	var r []*infoGroup
+	for _, ik := range infoKinds {
+		e, found := h.Enums[ik.enumName]
+		if !found {
+			continue
+		}
+		ec, found := config.Enums[ik.enumName]
+		if !found || ec.Skip {
+			continue
+		}
+		g := &infoGroup{
+			EnumGoName: ec.GoName,
+			CItemType:  strings.TrimSuffix(ik.enumName, "_enum") + "e",
+			CFunc:      ik.cFunc, CType: ik.cType, GoType: ik.goType,
+		}
+		for _, ev := range e.Values {
+			tag := strings.ToLower(strings.TrimPrefix(ev.Name, ik.prefix))
+			if v, found := config.InfoFieldRenames[ev.Name]; found {
+				tag = v
+			}
+			g.Items = append(g.Items, &infoItem{
+				CName:     ev.Name,
+				GoName:    ec.ConstantGoName(ev.Name, enumConstantPrefix),
+				FieldName: snakeToCamel(tag),
+				Tag:       tag,
+				GoType:    ik.goType,
+				Comment:   ec.ConstantComments[ev.Name],
+			})
+		}
+		r = append(r, g)
+	}
+
+	return r
+}
+
+type solverInfoTmplInput struct {
+	*OutputConfig
+	Groups []*infoGroup
+}
+
+// BuildSolverInfo writes the SolverInfo struct with all the information items, and the method to read them.
+func BuildSolverInfo(h *mskh.MosekH, config *OutputConfig, out io.Writer) error {
+	input := &solverInfoTmplInput{OutputConfig: config, Groups: buildInfoGroups(h, config)}
+
+	if err := solverInfoFileTmpl.Execute(out, input); err != nil {
+		return fmt.Errorf("failed to generate solver info: %w", err)
+	}
+	return nil
+}
+
+// BuildSolverInfoStubs writes the !cgo twin of the SolverInfo struct and the method, which always fails with [ErrNoMosek].
+func BuildSolverInfoStubs(h *mskh.MosekH, config *OutputConfig, out io.Writer) error {
+	input := &solverInfoTmplInput{OutputConfig: config, Groups: buildInfoGroups(h, config)}
+
+	if err := solverInfoFileTmpl.ExecuteTemplate(out, "nocgo", input); err != nil {
+		return fmt.Errorf("failed to generate solver info stubs: %w", err)
+	}
+	return nil
+}
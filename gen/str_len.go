@@ -0,0 +1,122 @@
+package gen
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/fardream/gen-gmsk/mskh"
+)
+
+// strLenPair pairs a string getter with the function returning the length of the string,
+// so the buffer is allocated with the exact size instead of MAX_STR_LEN.
+type strLenPair struct {
+	sizeParam *ParamConfig // size of the buffer passed to the getter, which is no longer a go parameter
+	lenFunc   *FuncConfig
+	lenCName  string   // C name of the length function
+	args      []string // go names of the getter parameters passed to the length function
+}
+
+// pairStrLen finds the length function of the string getter, which is str_len_func in config,
+// or the getter name with len appended, like MSK_getvarname and MSK_getvarnamelen.
+// The length function must take the leading parameters of the getter, and the next parameter
+// of the getter is the size of the buffer.
+func pairStrLen(f *mskh.MskFunction, config *OutputConfig) {
+	fc := config.Funcs[f.Name]
+	if fc == nil || fc.Skip || fc.StrLenFunc == "-" {
+		return
+	}
+	var strOuts []*ParamConfig
+	for _, pc := range fc.params {
+		if pc.IsStrOut {
+			strOuts = append(strOuts, pc)
+		}
+	}
+	if len(strOuts) != 1 {
+		return
+	}
+
+	explicit := fc.StrLenFunc != ""
+	lenFuncName := fc.StrLenFunc
+	if !explicit {
+		lenFuncName = f.Name + "len"
+	}
+	fail := func(format string, args ...any) {
+		if explicit {
+			log.Panicf("str_len_func of %s: %s", f.Name, fmt.Sprintf(format, args...))
+		}
+	}
+
+	lfc, found := config.Funcs[lenFuncName]
+	if !found || lfc.Skip {
+		fail("%s is not found or skipped", lenFuncName)
+		return
+	}
+	if lfc.FuncType != fc.FuncType && (lfc.IsTask() != fc.IsTask() || lfc.IsEnv() != fc.IsEnv()) {
+		fail("%s does not have the same receiver", lenFuncName)
+		return
+	}
+
+	var lenInputs, lenOutputs []*ParamConfig
+	for _, pc := range lfc.params {
+		switch {
+		case pc.IsTask || pc.IsEnv:
+		case pc.IsOutput:
+			lenOutputs = append(lenOutputs, pc)
+		default:
+			lenInputs = append(lenInputs, pc)
+		}
+	}
+	if len(lenOutputs) != 1 || lenOutputs[0].GoType != "int32" {
+		fail("%s does not return a single int32", lenFuncName)
+		return
+	}
+
+	start := 0
+	if fc.IsTask() || fc.IsEnv() {
+		start = 1
+	}
+	if len(fc.params) <= start+len(lenInputs) {
+		fail("%s has too many parameters", lenFuncName)
+		return
+	}
+	pair := &strLenPair{lenFunc: lfc, lenCName: lenFuncName}
+	for i, lpc := range lenInputs {
+		pc := fc.params[start+i]
+		if pc.IsOutput || pc.OrigCType != lpc.OrigCType {
+			fail("parameter %s does not match %s of %s", pc.CName, lpc.CName, lenFuncName)
+			return
+		}
+		pair.args = append(pair.args, pc.Name)
+	}
+	size := fc.params[start+len(lenInputs)]
+	if size.IsOutput || size.IsPointer || size.GoType != "int32" {
+		fail("parameter %s is not the size of the buffer", size.CName)
+		return
+	}
+	pair.sizeParam = size
+
+	fc.strLen = pair
+}
+
+// StrLenCall is the call to the length function of the string, empty if there is none.
+func (t *FuncTmplInput) StrLenCall() string {
+	if t.strLen == nil {
+		return ""
+	}
+	receiver := "task"
+	if t.IsEnv() {
+		receiver = "env"
+	}
+
+	return fmt.Sprintf("%s.%s(%s)", receiver, t.strLen.lenFunc.GoName, strings.Join(t.strLen.args, ", "))
+}
+
+// StrSizeName is the go name of the buffer size, which is a local variable when the length function is used.
+func (t *FuncTmplInput) StrSizeName() string {
+	if t.strLen == nil {
+		return ""
+	}
+
+	return t.strLen.sizeParam.Name
+}
@@ -0,0 +1,12 @@
+package gmsk
+
+// the helpers are declared with the same signatures with and without cgo.
+var (
+	_ func(*Task, OptimizerType) error                          = (*Task).SetOptimizer
+	_ func(*Task) (OptimizerType, error)                        = (*Task).GetOptimizer
+	_ func(*Task, *Params) error                                = (*Task).ApplyParams
+	_ func(*Task) (*Params, error)                              = (*Task).ReadParams
+	_ func(*Task) (*SolverInfo, error)                          = (*Task).GetSolverInfo
+	_ func(*Task, SolType, ...SolutionField) (*Solution, error) = (*Task).Solution
+	_ func(*Task, []float64, int32, int32) ([]float64, error)   = (*Task).AppendCSlice
+)
@@ -0,0 +1,60 @@
+//go:build gmsk_dlopen
+
+package gmsk
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestDlopen loads the fake library built as a shared object, whose path is in GMSK_TEST_LIB.
+// The library stays loaded, so the steps run in order in one test.
+func TestDlopen(t *testing.T) {
+	lib := os.Getenv("GMSK_TEST_LIB")
+	if lib == "" {
+		t.Skip("GMSK_TEST_LIB is not set")
+	}
+
+	if Loaded() {
+		t.Fatal("loaded before Load")
+	}
+	if _, _, _, err := GetVersion(); err == nil || err.Error() != RES_ERR_NOT_LOADED.String() {
+		t.Errorf("GetVersion before Load: expected %s, got %v", RES_ERR_NOT_LOADED, err)
+	}
+	if _, err := (&Task{}).AppendCSlice(nil, 2, 5); err == nil || err.Error() != RES_ERR_NOT_LOADED.String() {
+		t.Errorf("AppendCSlice before Load: expected %s, got %v", RES_ERR_NOT_LOADED, err)
+	}
+
+	if err := Load(lib + ".missing"); err == nil || !strings.Contains(err.Error(), "failed to load libmosek") {
+		t.Errorf("Load of a missing library: expected error, got %v", err)
+	}
+	if Loaded() {
+		t.Fatal("loaded after a failed Load")
+	}
+
+	if err := Load(lib); err != nil {
+		t.Fatal(err)
+	}
+	if !Loaded() {
+		t.Fatal("not loaded after Load")
+	}
+	if major, minor, revision, err := GetVersion(); err != nil || major != 10 || minor != 1 || revision != 0 {
+		t.Errorf("GetVersion: %d.%d.%d %v", major, minor, revision, err)
+	}
+	dst, err := (&Task{}).AppendCSlice([]float64{-1}, 2, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []float64{-1, 2, 3, 4}; !slices.Equal(dst, expected) {
+		t.Errorf("AppendCSlice: got %v, expected %v", dst, expected)
+	}
+	// the weak stubs in the library fail with MSK_RES_ERR_LICENSE.
+	if err := (&Task{}).PutCSlice(0, 1, []float64{1}); err == nil || err.Error() != RES_ERR_LICENSE.String() {
+		t.Errorf("PutCSlice: expected %s, got %v", RES_ERR_LICENSE, err)
+	}
+	if err := Load(lib); err == nil || !strings.Contains(err.Error(), "already loaded") {
+		t.Errorf("second Load: expected error, got %v", err)
+	}
+}
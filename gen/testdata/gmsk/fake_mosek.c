@@ -0,0 +1,58 @@
+//go:build !gmsk_dlopen
+
+// fake implementations of the mosek functions used by gmsk_test.go,
+// the other functions are weak stubs failing with MSK_RES_ERR_LICENSE.
+// With the dlopen backend they are loaded from the shared object built by TestGeneratedGmsk instead.
+
+#include <mosek.h>
+
+MSKrescodee MSK_getcslice(MSKtask_t task, MSKint32t first, MSKint32t last, MSKrealt *c)
+{
+    for (MSKint32t i = first; i < last; i++) {
+        c[i - first] = i;
+    }
+    return MSK_RES_OK;
+}
+
+// the string parameters are longer than MSK_MAX_STR_LEN.
+#define FAKE_STR_PARAM_LEN 2000
+
+MSKrescodee MSK_getintparam(MSKtask_t task, MSKiparame param, MSKint32t *parvalue)
+{
+    *parvalue = 1;
+    return MSK_RES_OK;
+}
+
+MSKrescodee MSK_getdouparam(MSKtask_t task, MSKdparame param, MSKrealt *parvalue)
+{
+    *parvalue = 0.5;
+    return MSK_RES_OK;
+}
+
+MSKrescodee MSK_getstrparamlen(MSKtask_t task, MSKsparame param, MSKint32t *len)
+{
+    *len = FAKE_STR_PARAM_LEN;
+    return MSK_RES_OK;
+}
+
+MSKrescodee MSK_getstrparam(MSKtask_t task, MSKsparame param, MSKint32t maxlen, MSKint32t *len, char *parvalue)
+{
+    if (maxlen <= FAKE_STR_PARAM_LEN) {
+        return MSK_RES_ERR_ARGUMENT_IS_TOO_SMALL;
+    }
+    for (MSKint32t i = 0; i < FAKE_STR_PARAM_LEN; i++) {
+        parvalue[i] = 'a';
+    }
+    parvalue[FAKE_STR_PARAM_LEN] = 0;
+    *len = FAKE_STR_PARAM_LEN;
+    return MSK_RES_OK;
+}
+
+// the library is of another version than mosek.h.
+MSKrescodee MSK_getversion(MSKint32t *major, MSKint32t *minor, MSKint32t *revision)
+{
+    *major = 10;
+    *minor = 1;
+    *revision = 0;
+    return MSK_RES_OK;
+}
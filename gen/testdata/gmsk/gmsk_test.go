@@ -0,0 +1,101 @@
+//go:build cgo
+
+package gmsk
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAppendCSlice(t *testing.T) {
+	task := &Task{}
+	dst, err := task.AppendCSlice([]float64{-1}, 2, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []float64{-1, 2, 3, 4}; !slices.Equal(dst, expected) {
+		t.Errorf("got %v, expected %v", dst, expected)
+	}
+}
+
+func TestAppendNegativeLength(t *testing.T) {
+	task := &Task{}
+	dst := []float64{-1}
+	r, err := task.AppendCSlice(dst, 5, 2)
+	if err == nil || err.Error() != RES_ERR_ARGUMENT_IS_TOO_SMALL.String() {
+		t.Errorf("expected %s, got %v", RES_ERR_ARGUMENT_IS_TOO_SMALL, err)
+	}
+	if !slices.Equal(r, dst) {
+		t.Errorf("dst is changed to %v", r)
+	}
+}
+
+func TestAppendAllocs(t *testing.T) {
+	task := &Task{}
+	dst := make([]float64, 0, 16)
+	allocs := testing.AllocsPerRun(100, func() {
+		var err error
+		if dst, err = task.AppendCSlice(dst[:0], 0, 10); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("AppendCSlice allocates %v times with enough capacity", allocs)
+	}
+}
+
+// benchCSliceLen is the length of the slices read by the benchmarks.
+const benchCSliceLen = 1000
+
+// BenchmarkGetCSlice allocates the slice for every call of the plain getter.
+func BenchmarkGetCSlice(b *testing.B) {
+	task := &Task{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c := make([]float64, benchCSliceLen)
+		if err := task.GetCSlice(0, benchCSliceLen, c); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkAppendCSlice reuses the buffer, which does not allocate after the first call.
+func BenchmarkAppendCSlice(b *testing.B) {
+	task := &Task{}
+	var dst []float64
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var err error
+		if dst, err = task.AppendCSlice(dst[:0], 0, benchCSliceLen); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestReadParams(t *testing.T) {
+	task := &Task{}
+	p, err := task.ReadParams()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Log == nil || *p.Log != 1 || p.OptimizerMaxTime == nil || *p.OptimizerMaxTime != 0.5 {
+		t.Errorf("unexpected parameters %+v", p)
+	}
+	if p.BasSolFileName == nil || len(*p.BasSolFileName) != 2000 {
+		t.Errorf("string parameter longer than MAX_STR_LEN is not read")
+	}
+}
+
+func TestCheckVersionInMakeEnv(t *testing.T) {
+	defer func(v bool) { CheckVersionInMakeEnv = v }(CheckVersionInMakeEnv)
+
+	CheckVersionInMakeEnv = false
+	if _, err := MakeEnv(""); err != nil {
+		t.Errorf("MakeEnv fails without the version check: %v", err)
+	}
+	CheckVersionInMakeEnv = true
+	if _, err := MakeEnv(""); err == nil || !strings.Contains(err.Error(), "10.1.0") {
+		t.Errorf("MakeEnv does not fail with the version of the library: %v", err)
+	}
+}
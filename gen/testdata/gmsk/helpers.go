@@ -0,0 +1,48 @@
+//go:build cgo
+
+// Hand-written parts of gmsk needed by the generated files, the real ones are in github.com/fardream/gmsk.
+
+package gmsk
+
+// #include <mosek.h>
+import "C"
+
+import "errors"
+
+const MAX_STR_LEN = 1024
+
+type Env struct{ env C.MSKenv_t }
+
+func (e *Env) getEnv() C.MSKenv_t { return e.env }
+
+type Task struct{ task C.MSKtask_t }
+
+func getPtrToFirst[T any](s []T) *T {
+	if len(s) == 0 {
+		return nil
+	}
+	return &s[0]
+}
+
+func boolToInt(b bool) C.MSKbooleant {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func intToBool(i C.MSKbooleant) bool { return i != 0 }
+
+func (r ResCode) ToError() error {
+	if r == RES_OK {
+		return nil
+	}
+	return errors.New(r.String())
+}
+
+func MakeEnv(dbgfile string) (*Env, error) {
+	if err := checkVersionInMakeEnv(); err != nil {
+		return nil, err
+	}
+	return &Env{}, nil
+}
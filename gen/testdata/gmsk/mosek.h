@@ -0,0 +1,269 @@
+#ifndef MOSEK_H
+#define MOSEK_H
+
+#include <stdint.h>
+#include <stddef.h>
+
+#define MSK_VERSION_MAJOR    11
+#define MSK_VERSION_MINOR    2
+#define MSK_VERSION_REVISION 3
+#define MSK_VERSION_STATE    ""
+
+#define MSKAPI
+
+enum MSKrescode_enum {
+  MSK_RES_OK = 0,
+  MSK_RES_WRN_OPEN_PARAM_FILE = 50,
+  MSK_RES_ERR_LICENSE = 1000,
+  MSK_RES_ERR_ARGUMENT_IS_TOO_SMALL = 5004,
+  MSK_RES_TRM_MAX_ITERATIONS = 100000
+};
+
+enum MSKonoffkey_enum {
+  MSK_OFF = 0,
+  MSK_ON = 1
+};
+
+enum MSKoptimizertype_enum {
+  MSK_OPTIMIZER_CONIC = 0,
+  MSK_OPTIMIZER_DUAL_SIMPLEX = 1,
+  MSK_OPTIMIZER_FREE = 2,
+  MSK_OPTIMIZER_FREE_SIMPLEX = 3,
+  MSK_OPTIMIZER_INTPNT = 4,
+  MSK_OPTIMIZER_MIXED_INT = 5,
+  MSK_OPTIMIZER_NEW_DUAL_SIMPLEX = 6,
+  MSK_OPTIMIZER_NEW_PRIMAL_SIMPLEX = 7,
+  MSK_OPTIMIZER_PRIMAL_SIMPLEX = 8
+};
+
+enum MSKpresolvemode_enum {
+  MSK_PRESOLVE_MODE_OFF = 0,
+  MSK_PRESOLVE_MODE_ON = 1,
+  MSK_PRESOLVE_MODE_FREE = 2
+};
+
+enum MSKsoltype_enum {
+  MSK_SOL_ITR = 0,
+  MSK_SOL_BAS = 1,
+  MSK_SOL_ITG = 2
+};
+
+enum MSKsolsta_enum {
+  MSK_SOL_STA_UNKNOWN = 0,
+  MSK_SOL_STA_OPTIMAL = 1
+};
+
+enum MSKprosta_enum {
+  MSK_PRO_STA_UNKNOWN = 0,
+  MSK_PRO_STA_PRIM_AND_DUAL_FEAS = 1
+};
+
+enum MSKstakey_enum {
+  MSK_SK_UNK = 0,
+  MSK_SK_BAS = 1
+};
+
+enum MSKiparam_enum {
+  MSK_IPAR_ANA_SOL_BASIS = 0,
+  MSK_IPAR_INTPNT_BASIS = 17,
+  MSK_IPAR_LOG = 34,
+  MSK_IPAR_MIO_NODE_SELECTION = 83,
+  MSK_IPAR_NUM_THREADS = 100,
+  MSK_IPAR_OPTIMIZER = 110,
+  MSK_IPAR_PRESOLVE_USE = 121
+};
+
+enum MSKmionodeseltype_enum {
+  MSK_MIO_NODE_SELECTION_FREE = 0,
+  MSK_MIO_NODE_SELECTION_FIRST = 1,
+  MSK_MIO_NODE_SELECTION_BEST = 2,
+  MSK_MIO_NODE_SELECTION_PSEUDO = 3
+};
+
+enum MSKdparam_enum {
+  MSK_DPAR_INTPNT_TOL_PFEAS = 33,
+  MSK_DPAR_OPTIMIZER_MAX_TIME = 50
+};
+
+enum MSKsparam_enum {
+  MSK_SPAR_BAS_SOL_FILE_NAME = 0,
+  MSK_SPAR_PARAM_COMMENT_SIGN = 6
+};
+
+enum MSKdinfitem_enum {
+  MSK_DINF_INTPNT_PRIMAL_OBJ = 18,
+  MSK_DINF_OPTIMIZER_TIME = 50
+};
+
+enum MSKiinfitem_enum {
+  MSK_IINF_INTPNT_ITER = 17,
+  MSK_IINF_OPT_NUMVAR = 105
+};
+
+enum MSKliinfitem_enum {
+  MSK_LIINF_MIO_NUM_NODES = 0,
+  MSK_LIINF_SIMPLEX_ITER = 21
+};
+
+enum MSKvalue_enum {
+  MSK_LICENSE_BUFFER_LENGTH = 21,
+  MSK_MAX_STR_LEN = 1024
+};
+
+enum MSKsolitem_enum {
+  MSK_SOL_ITEM_XC = 0,
+  MSK_SOL_ITEM_XX = 1,
+  MSK_SOL_ITEM_Y = 2,
+  MSK_SOL_ITEM_SLC = 3,
+  MSK_SOL_ITEM_SUC = 4,
+  MSK_SOL_ITEM_SLX = 5,
+  MSK_SOL_ITEM_SUX = 6,
+  MSK_SOL_ITEM_SNX = 7
+};
+
+enum MSKinftype_enum {
+  MSK_INF_DOU_TYPE = 0,
+  MSK_INF_INT_TYPE = 1,
+  MSK_INF_LINT_TYPE = 2
+};
+
+enum MSKstreamtype_enum {
+  MSK_STREAM_LOG = 0,
+  MSK_STREAM_MSG = 1
+};
+
+enum MSKcompresstype_enum {
+  MSK_COMPRESS_NONE = 0,
+  MSK_COMPRESS_FREE = 1
+};
+
+enum MSKorderingtype_enum {
+  MSK_ORDER_METHOD_FREE = 0,
+  MSK_ORDER_METHOD_APPMINLOC = 1
+};
+
+typedef int MSKbooleant;
+typedef int MSKint32t;
+typedef long long MSKint64t;
+typedef double MSKrealt;
+typedef char * MSKstring_t;
+typedef void * MSKenv_t;
+typedef void * MSKtask_t;
+typedef void * MSKuserhandle_t;
+typedef size_t (MSKAPI * MSKhwritefunc) (MSKuserhandle_t handle, const void * src, const size_t count);
+
+typedef enum MSKrescode_enum MSKrescodee;
+typedef enum MSKonoffkey_enum MSKonoffkeye;
+typedef enum MSKoptimizertype_enum MSKoptimizertypee;
+typedef enum MSKpresolvemode_enum MSKpresolvemodee;
+typedef enum MSKsoltype_enum MSKsoltypee;
+typedef enum MSKsolsta_enum MSKsolstae;
+typedef enum MSKprosta_enum MSKprostae;
+typedef enum MSKstakey_enum MSKstakeye;
+typedef enum MSKiparam_enum MSKiparame;
+typedef enum MSKdparam_enum MSKdparame;
+typedef enum MSKsparam_enum MSKsparame;
+typedef enum MSKdinfitem_enum MSKdinfiteme;
+typedef enum MSKiinfitem_enum MSKiinfiteme;
+typedef enum MSKliinfitem_enum MSKliinfiteme;
+typedef enum MSKsolitem_enum MSKsoliteme;
+typedef enum MSKinftype_enum MSKinftypee;
+typedef enum MSKstreamtype_enum MSKstreamtypee;
+typedef enum MSKorderingtype_enum MSKorderingtypee;
+typedef enum MSKmionodeseltype_enum MSKmionodeseltypee;
+typedef enum MSKcompresstype_enum MSKcompresstypee;
+
+/* Environment and task life cycle */
+MSKrescodee (MSKAPI MSK_makeenv) (MSKenv_t * env, const char * dbgfile);
+MSKrescodee (MSKAPI MSK_deleteenv) (MSKenv_t * env);
+MSKrescodee (MSKAPI MSK_maketask) (MSKenv_t env, MSKint32t maxnumcon, MSKint32t maxnumvar, MSKtask_t * task);
+MSKrescodee (MSKAPI MSK_freeenv) (MSKenv_t env, void * buffer);
+MSKrescodee (MSKAPI MSK_freetask) (MSKtask_t task, void * buffer);
+
+MSKrescodee (MSKAPI MSK_getversion) (MSKint32t * major, MSKint32t * minor, MSKint32t * revision);
+MSKrescodee (MSKAPI MSK_getcodedesc) (MSKrescodee code, char * symname, char * str);
+MSKrescodee (MSKAPI MSK_rescodetostr) (MSKrescodee res, char * str);
+MSKrescodee (MSKAPI MSK_axpy) (MSKenv_t env, MSKint32t n, MSKrealt alpha, const MSKrealt * x, MSKrealt * y);
+MSKrescodee (MSKAPI MSK_computesparsecholesky) (MSKenv_t env, MSKint32t numthreads, MSKint32t ordermethod, MSKrealt tolsingular, MSKint32t n, const MSKint32t * anzc, const MSKint64t * aptrc, const MSKint32t * asubc, const MSKrealt * avalc, MSKint32t ** perm, MSKrealt ** diag, MSKint32t ** lnzc, MSKint64t ** lptrc, MSKint64t * lensubnval, MSKint32t ** lsubc, MSKrealt ** lvalc);
+
+/* parameters */
+MSKrescodee (MSKAPI MSK_putintparam) (MSKtask_t task, MSKiparame param, MSKint32t parvalue);
+MSKrescodee (MSKAPI MSK_getintparam) (MSKtask_t task, MSKiparame param, MSKint32t * parvalue);
+MSKrescodee (MSKAPI MSK_putdouparam) (MSKtask_t task, MSKdparame param, MSKrealt parvalue);
+MSKrescodee (MSKAPI MSK_getdouparam) (MSKtask_t task, MSKdparame param, MSKrealt * parvalue);
+MSKrescodee (MSKAPI MSK_putstrparam) (MSKtask_t task, MSKsparame param, const char * parvalue);
+MSKrescodee (MSKAPI MSK_getstrparam) (MSKtask_t task, MSKsparame param, MSKint32t maxlen, MSKint32t * len, char * parvalue);
+MSKrescodee (MSKAPI MSK_getstrparamlen) (MSKtask_t task, MSKsparame param, MSKint32t * len);
+MSKrescodee (MSKAPI MSK_getstrparamal) (MSKtask_t task, MSKsparame param, MSKint32t numaddchr, MSKstring_t * value);
+MSKrescodee (MSKAPI MSK_getnastrparamal) (MSKtask_t task, const char * paramname, MSKint32t numaddchr, MSKstring_t * value);
+MSKrescodee (MSKAPI MSK_isintparname) (MSKtask_t task, const char * parname, MSKiparame * param);
+MSKrescodee (MSKAPI MSK_readparamfile) (MSKtask_t task, const char * filename);
+MSKrescodee (MSKAPI MSK_writeparamfile) (MSKtask_t task, const char * filename);
+
+/* info */
+MSKrescodee (MSKAPI MSK_getdouinf) (MSKtask_t task, MSKdinfiteme whichdinf, MSKrealt * dvalue);
+MSKrescodee (MSKAPI MSK_getintinf) (MSKtask_t task, MSKiinfiteme whichiinf, MSKint32t * ivalue);
+MSKrescodee (MSKAPI MSK_getlintinf) (MSKtask_t task, MSKliinfiteme whichliinf, MSKint64t * ivalue);
+MSKrescodee (MSKAPI MSK_getinfmax) (MSKtask_t task, MSKinftypee inftype, MSKint32t * infmax);
+
+/* problem dimensions */
+MSKrescodee (MSKAPI MSK_getnumvar) (MSKtask_t task, MSKint32t * numvar);
+MSKrescodee (MSKAPI MSK_getnumcon) (MSKtask_t task, MSKint32t * numcon);
+MSKrescodee (MSKAPI MSK_getnumbarvar) (MSKtask_t task, MSKint32t * numbarvar);
+MSKrescodee (MSKAPI MSK_getnumcone) (MSKtask_t task, MSKint32t * numcone);
+MSKrescodee (MSKAPI MSK_getaccntot) (MSKtask_t task, MSKint64t * n);
+MSKrescodee (MSKAPI MSK_getlenbarvarj) (MSKtask_t task, MSKint32t j, MSKint64t * lenbarvarj);
+MSKrescodee (MSKAPI MSK_appendvars) (MSKtask_t task, MSKint32t num);
+MSKrescodee (MSKAPI MSK_putmaxnumvar) (MSKtask_t task, MSKint32t maxnumvar);
+MSKrescodee (MSKAPI MSK_getmaxnumvar) (MSKtask_t task, MSKint32t * maxnumvar);
+MSKrescodee (MSKAPI MSK_putcslice) (MSKtask_t task, MSKint32t first, MSKint32t last, const MSKrealt * slice);
+MSKrescodee (MSKAPI MSK_getcslice) (MSKtask_t task, MSKint32t first, MSKint32t last, MSKrealt * c);
+MSKrescodee (MSKAPI MSK_getclist) (MSKtask_t task, MSKint32t num, const MSKint32t * subj, MSKrealt * c);
+MSKrescodee (MSKAPI MSK_getacolslicenumnz64) (MSKtask_t task, MSKint32t first, MSKint32t last, MSKint64t * numnz);
+MSKrescodee (MSKAPI MSK_getacolslice64) (MSKtask_t task, MSKint32t first, MSKint32t last, MSKint64t maxnumnz, MSKint64t * ptrb, MSKint64t * ptre, MSKint32t * sub, MSKrealt * val);
+MSKrescodee (MSKAPI MSK_getaij) (MSKtask_t task, MSKint32t i, MSKint32t j, MSKrealt * aij);
+MSKrescodee (MSKAPI MSK_getarownumnz) (MSKtask_t task, MSKint32t i, MSKint32t * nzi);
+MSKrescodee (MSKAPI MSK_getarow) (MSKtask_t task, MSKint32t i, MSKint32t * nzi, MSKint32t * subi, MSKrealt * vali);
+
+/* names */
+MSKrescodee (MSKAPI MSK_putvarname) (MSKtask_t task, MSKint32t j, const char * name);
+MSKrescodee (MSKAPI MSK_getvarname) (MSKtask_t task, MSKint32t j, MSKint32t sizename, char * name);
+MSKrescodee (MSKAPI MSK_getvarnamelen) (MSKtask_t task, MSKint32t i, MSKint32t * len);
+MSKrescodee (MSKAPI MSK_getconname) (MSKtask_t task, MSKint32t i, MSKint32t sizename, char * name);
+MSKrescodee (MSKAPI MSK_getconnamelen) (MSKtask_t task, MSKint32t i, MSKint32t * len);
+MSKrescodee (MSKAPI MSK_gettaskname) (MSKtask_t task, MSKint32t sizetaskname, char * taskname);
+MSKrescodee (MSKAPI MSK_gettasknamelen) (MSKtask_t task, MSKint32t * len);
+MSKrescodee (MSKAPI MSK_getobjname) (MSKtask_t task, MSKint32t sizeobjname, char * objname);
+MSKrescodee (MSKAPI MSK_getvarnameindex) (MSKtask_t task, const char * somename, MSKint32t * asgn, MSKint32t * index);
+MSKrescodee (MSKAPI MSK_generatevarnames) (MSKtask_t task, MSKint32t num, const MSKint32t * subj, const char * fmt, MSKint32t ndims, const MSKint32t * dims, const MSKint64t * sp, MSKint32t numnamedaxis, const MSKint32t * namedaxisidxs, const MSKint64t * numnames, const char ** names);
+
+/* solution */
+MSKrescodee (MSKAPI MSK_solutiondef) (MSKtask_t task, MSKsoltypee whichsol, MSKbooleant * isdef);
+MSKrescodee (MSKAPI MSK_getsolsta) (MSKtask_t task, MSKsoltypee whichsol, MSKsolstae * solutionsta);
+MSKrescodee (MSKAPI MSK_getprosta) (MSKtask_t task, MSKsoltypee whichsol, MSKprostae * problemsta);
+MSKrescodee (MSKAPI MSK_getxx) (MSKtask_t task, MSKsoltypee whichsol, MSKrealt * xx);
+MSKrescodee (MSKAPI MSK_getxxslice) (MSKtask_t task, MSKsoltypee whichsol, MSKint32t first, MSKint32t last, MSKrealt * xx);
+MSKrescodee (MSKAPI MSK_getxc) (MSKtask_t task, MSKsoltypee whichsol, MSKrealt * xc);
+MSKrescodee (MSKAPI MSK_gety) (MSKtask_t task, MSKsoltypee whichsol, MSKrealt * y);
+MSKrescodee (MSKAPI MSK_getslc) (MSKtask_t task, MSKsoltypee whichsol, MSKrealt * slc);
+MSKrescodee (MSKAPI MSK_getsuc) (MSKtask_t task, MSKsoltypee whichsol, MSKrealt * suc);
+MSKrescodee (MSKAPI MSK_getslx) (MSKtask_t task, MSKsoltypee whichsol, MSKrealt * slx);
+MSKrescodee (MSKAPI MSK_getsux) (MSKtask_t task, MSKsoltypee whichsol, MSKrealt * sux);
+MSKrescodee (MSKAPI MSK_getsnx) (MSKtask_t task, MSKsoltypee whichsol, MSKrealt * snx);
+MSKrescodee (MSKAPI MSK_getskc) (MSKtask_t task, MSKsoltypee whichsol, MSKstakeye * skc);
+MSKrescodee (MSKAPI MSK_getskx) (MSKtask_t task, MSKsoltypee whichsol, MSKstakeye * skx);
+MSKrescodee (MSKAPI MSK_getskn) (MSKtask_t task, MSKsoltypee whichsol, MSKstakeye * skn);
+MSKrescodee (MSKAPI MSK_getbarxj) (MSKtask_t task, MSKsoltypee whichsol, MSKint32t j, MSKrealt * barxj);
+MSKrescodee (MSKAPI MSK_getbarsj) (MSKtask_t task, MSKsoltypee whichsol, MSKint32t j, MSKrealt * barsj);
+MSKrescodee (MSKAPI MSK_getaccdotys) (MSKtask_t task, MSKsoltypee whichsol, MSKrealt * doty);
+MSKrescodee (MSKAPI MSK_getsolution) (MSKtask_t task, MSKsoltypee whichsol, MSKprostae * problemsta, MSKsolstae * solutionsta, MSKstakeye * skc, MSKstakeye * skx, MSKstakeye * skn, MSKrealt * xc, MSKrealt * xx, MSKrealt * y, MSKrealt * slc, MSKrealt * suc, MSKrealt * slx, MSKrealt * sux, MSKrealt * snx);
+MSKrescodee (MSKAPI MSK_getprimalobj) (MSKtask_t task, MSKsoltypee whichsol, MSKrealt * primalobj);
+
+/* io */
+MSKrescodee (MSKAPI MSK_writebsolutionhandle) (MSKtask_t task, MSKhwritefunc func, MSKuserhandle_t handle, MSKcompresstypee compress);
+MSKrescodee (MSKAPI MSK_optimizetrm) (MSKtask_t task, MSKrescodee * trmcode);
+MSKrescodee (MSKAPI MSK_getcone) (MSKtask_t task, MSKint32t k, MSKint32t * ct, MSKrealt * conepar, MSKint32t * nummem, MSKint32t * submem);
+MSKbooleant (MSKAPI MSK_checkmemtask) (MSKtask_t task, const char * file, MSKint32t line);
+MSKrescodee (MSKAPI MSK_echotask) (MSKtask_t task, MSKstreamtypee whichstream, const char * format, ...);
+
+#endif
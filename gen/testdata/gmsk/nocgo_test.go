@@ -0,0 +1,34 @@
+//go:build !cgo
+
+package gmsk
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNoMosek(t *testing.T) {
+	task := &Task{}
+	if err := task.SetOptimizer(OPTIMIZER_INTPNT); !errors.Is(err, ErrNoMosek) {
+		t.Errorf("SetOptimizer: expected ErrNoMosek, got %v", err)
+	}
+	if err := task.ApplyParams(&Params{}); !errors.Is(err, ErrNoMosek) {
+		t.Errorf("ApplyParams: expected ErrNoMosek, got %v", err)
+	}
+	if _, err := task.ReadParams(); !errors.Is(err, ErrNoMosek) {
+		t.Errorf("ReadParams: expected ErrNoMosek, got %v", err)
+	}
+	if _, err := task.GetSolverInfo(); !errors.Is(err, ErrNoMosek) {
+		t.Errorf("GetSolverInfo: expected ErrNoMosek, got %v", err)
+	}
+	if _, err := task.Solution(SOL_ITR, SOLUTION_XX); !errors.Is(err, ErrNoMosek) {
+		t.Errorf("Solution: expected ErrNoMosek, got %v", err)
+	}
+	if _, err := task.AppendCSlice(nil, 0, 1); !errors.Is(err, ErrNoMosek) {
+		t.Errorf("AppendCSlice: expected ErrNoMosek, got %v", err)
+	}
+	// without an error to return, the stub returns the zero value instead of panicking.
+	if task.CheckMemtask("file", 1) {
+		t.Error("CheckMemtask: expected false")
+	}
+}
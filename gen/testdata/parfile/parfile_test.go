@@ -0,0 +1,44 @@
+package parfile
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const roundTrip = `BEGIN MOSEK
+MSK_IPAR_LOG 10
+MSK_IPAR_OPTIMIZER MSK_OPTIMIZER_INTPNT
+MSK_DPAR_OPTIMIZER_MAX_TIME 1.5
+MSK_SPAR_PARAM_READ_FILE_NAME "a b.par"
+END MOSEK
+`
+
+func TestRoundTrip(t *testing.T) {
+	f, err := Read(strings.NewReader(roundTrip))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if err := f.Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != roundTrip {
+		t.Errorf("written file is\n%s\nexpected\n%s", b.String(), roundTrip)
+	}
+}
+
+func TestReject(t *testing.T) {
+	for _, line := range []string{
+		"MSK_IPAR_OPTIMIZER MSK_ON",      // symbol of another enum
+		"MSK_IPAR_OPTIMIZER 2",           // integer for an enum valued parameter
+		"MSK_IPAR_OPTIMIZER MSK_UNKNOWN", // unknown symbol
+		"MSK_IPAR_LOG MSK_ON",            // symbol for a plain integer parameter
+		"MSK_IPAR_LOG MSK_OPTIMIZER_INTPNT",
+		"MSK_DPAR_OPTIMIZER_MAX_TIME MSK_ON",
+	} {
+		if _, err := Read(strings.NewReader("BEGIN MOSEK\n" + line + "\nEND MOSEK\n")); err == nil {
+			t.Errorf("%s is accepted", line)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package gen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/fardream/gen-gmsk/mskh"
+)
+
+// getVersionFunc is the function CheckVersion gets the version of the library with.
+const getVersionFunc = "MSK_getversion"
+
+// makeEnvHook is the generated function the hand written MakeEnv must call before creating the environment.
+const makeEnvHook = "checkVersionInMakeEnv"
+
+type versionFileInput struct {
+	PackageName           string
+	Version               mskh.MskVersion
+	CheckVersionInMakeEnv bool
+	GetVersion            string // go name of MSK_getversion
+}
+
+// BuildVersion generates the version of the header and CheckVersion comparing it with the loaded library.
+func BuildVersion(h *mskh.MosekH, config *OutputConfig, out io.Writer) error {
+	if h.Version.Major == 0 {
+		return fmt.Errorf("version of mosek.h is unknown, MSK_VERSION_MAJOR is not found")
+	}
+	fc, found := config.Funcs[getVersionFunc]
+	if !found || fc.Skip {
+		return fmt.Errorf("%s is not generated, which is called by CheckVersion", getVersionFunc)
+	}
+
+	return versionFileTmpl.Execute(out, &versionFileInput{
+		PackageName:           config.PackageName,
+		Version:               h.Version,
+		CheckVersionInMakeEnv: config.CheckVersionInMakeEnv,
+		GetVersion:            fc.GoName,
+	})
+}
+
+// CheckMakeEnvHook checks the hand written MakeEnv in the gmsk dir calls checkVersionInMakeEnv,
+// without which CheckVersionInMakeEnv has no effect. Only a warning is logged if MakeEnv is not found,
+// and for the files that cannot be parsed.
+func CheckMakeEnvHook(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".go" {
+			continue
+		}
+		fileName := filepath.Join(dir, e.Name())
+		f, err := parser.ParseFile(token.NewFileSet(), fileName, nil, parser.SkipObjectResolution)
+		if err != nil {
+			log.Printf("skip %s when looking for MakeEnv: %v", fileName, err)
+			continue
+		}
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || fn.Name.Name != "MakeEnv" || fn.Body == nil {
+				continue
+			}
+			found = true
+			calls := false
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				if call, ok := n.(*ast.CallExpr); ok {
+					if id, ok := call.Fun.(*ast.Ident); ok && id.Name == makeEnvHook {
+						calls = true
+					}
+				}
+				return !calls
+			})
+			if !calls {
+				return fmt.Errorf("MakeEnv in %s does not call %s(), which makes CheckVersionInMakeEnv have no effect", fileName, makeEnvHook)
+			}
+		}
+	}
+	if !found {
+		log.Printf("cannot find MakeEnv in %s, it must call %s() before creating the environment", dir, makeEnvHook)
+	}
+
+	return nil
+}
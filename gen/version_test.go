@@ -0,0 +1,36 @@
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckMakeEnvHook(t *testing.T) {
+	for _, c := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{"calls", "package gmsk\n\nfunc MakeEnv() (*Env, error) {\n\tif err := checkVersionInMakeEnv(); err != nil {\n\t\treturn nil, err\n\t}\n\treturn &Env{}, nil\n}\n", ""},
+		{"no call", "package gmsk\n\nfunc MakeEnv() (*Env, error) {\n\treturn &Env{}, nil\n}\n", "does not call checkVersionInMakeEnv()"},
+		{"method", "package gmsk\n\nfunc (e *Env) MakeEnv() {}\n", ""},
+		{"no MakeEnv", "package gmsk\n", ""},
+		{"not parsable", "package gmsk\n\nfunc MakeEnv( {\n", ""},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "env.go"), []byte(c.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			err := CheckMakeEnvHook(dir)
+			switch {
+			case c.err == "" && err != nil:
+				t.Errorf("unexpected error %v", err)
+			case c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)):
+				t.Errorf("expected error with %q, got %v", c.err, err)
+			}
+		})
+	}
+}
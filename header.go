@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"strings"
+
+	"github.com/fardream/gen-gmsk/mskh"
+)
+
+// stringsFlag is a flag that can be repeated.
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringsFlag) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+// headerFlags are the flags locating and parsing mosek.h, shared by the commands.
+type headerFlags struct {
+	fileName     string
+	mosekVersion string
+	includePaths stringsFlag
+	defines      stringsFlag
+
+	found    *mskh.Install
+	searched bool
+}
+
+func addHeaderFlags(fs *flag.FlagSet) *headerFlags {
+	f := &headerFlags{}
+	fs.StringVar(&f.fileName, "filename", f.fileName, "path to mosek.h, found in $MSKHOME, $MOSEK_HOME, ~/mosek and /opt/mosek if empty")
+	fs.StringVar(&f.mosekVersion, "mosek-version", f.mosekVersion, "version of mosek to find, for example 11.2, the newest if empty")
+	fs.Var(&f.includePaths, "I", "include path for parsing mosek.h, can be repeated")
+	fs.Var(&f.defines, "D", "macro NAME or NAME=VALUE for parsing mosek.h, can be repeated")
+	return f
+}
+
+// install finds the mosek installation and sets the path to mosek.h if it is not given,
+// otherwise it is the installation of the given mosek.h, which is nil if the header is not in an installation.
+func (f *headerFlags) install() *mskh.Install {
+	if f.searched {
+		return f.found
+	}
+	f.searched = true
+	if f.fileName != "" {
+		f.found = mskh.InstallOfHeader(f.fileName)
+		return f.found
+	}
+	f.found = getOrPanic(mskh.FindInstall(f.mosekVersion))
+	f.fileName = f.found.HeaderPath()
+	log.Printf("found mosek %s for %s in %s", f.found.Version, f.found.Platform, f.found.Dir)
+
+	return f.found
+}
+
+// parse parses mosek.h.
+func (f *headerFlags) parse() *mskh.MosekH {
+	f.install()
+	return getOrPanic(mskh.Parse(f.fileName, &mskh.ParseOptions{IncludePaths: f.includePaths, Defines: f.defines}))
+}
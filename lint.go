@@ -0,0 +1,27 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/fardream/gen-gmsk/gen"
+)
+
+// runLint is the lint command, which checks config.yml against mosek.h.
+func runLint(args []string) {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	header := addHeaderFlags(fs)
+	orPanic(fs.Parse(args))
+
+	m := header.parse()
+	problems := getOrPanic(gen.Lint(m))
+
+	for _, p := range problems {
+		fmt.Fprintln(os.Stdout, p)
+	}
+	if len(problems) > 0 {
+		log.Panicf("%d problems found in config.yml", len(problems))
+	}
+}
@@ -1,19 +1,17 @@
 package main
 
 import (
-	"bytes"
 	"encoding/json"
 	"flag"
-	"fmt"
-	"io"
 	"log"
 	"os"
 	"path"
-	"runtime"
-	"strings"
 
-	"modernc.org/cc/v4"
-	"mvdan.cc/gofumpt/format"
+	"github.com/fardream/gen-gmsk/emit"
+	"github.com/fardream/gen-gmsk/gen"
+	"github.com/fardream/gen-gmsk/model"
+	"github.com/fardream/gen-gmsk/mskh"
+	"github.com/goccy/go-yaml"
 )
 
 func orPanic(err error) {
@@ -27,57 +25,66 @@ func getOrPanic[T any](a T, err error) T {
 	return a
 }
 
-func builderToFile(outputDir, outFile string, h *MosekH, config *OutputConfig, buildFunc func(*MosekH, *OutputConfig, io.Writer) error) {
-	var fileContent bytes.Buffer
-	orPanic(buildFunc(h, config, &fileContent))
-	formattedContent, err := format.Source(fileContent.Bytes(), format.Options{
-		LangVersion: "go1.21",
-		ExtraRules:  true,
-		ModulePath:  "github.com/fardream/gmsk/v11",
-	})
+// writeModel writes the API model as yaml or json, depending on the extension of the file.
+func writeModel(fileName string, api *model.API) error {
+	var b []byte
+	var err error
+	switch path.Ext(fileName) {
+	case ".yml", ".yaml":
+		b, err = yaml.Marshal(api)
+	default:
+		b, err = json.MarshalIndent(api, "", "  ")
+	}
 	if err != nil {
-		fullPath := path.Join(outputDir, outFile)
-		fmt.Fprintf(os.Stderr, "failed to format %s due to %s", fullPath, err.Error())
-		orPanic(os.WriteFile(fullPath, fileContent.Bytes(), 0o644))
-		orPanic(err)
+		return err
 	}
 
-	if outputDir != "" {
-		fullPath := path.Join(outputDir, outFile)
-		orPanic(os.WriteFile(fullPath, formattedContent, 0o644))
-	} else {
-		os.Stdout.Write(formattedContent)
-	}
+	return os.WriteFile(fileName, b, 0o644)
 }
 
 func main() {
-	homeDir := getOrPanic(os.UserHomeDir())
-	fileName := path.Join(homeDir, "mosek", "11.2", "tools", "platform", "linux64x86", "h", "mosek.h")
-	flag.StringVar(&fileName, "filename", fileName, "path to mosek.h")
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "names":
+			runNames(os.Args[2:])
+			return
+		case "lint":
+			runLint(os.Args[2:])
+			return
+		}
+	}
+
+	header := addHeaderFlags(flag.CommandLine)
 
 	outputFile := ""
 	flag.StringVar(&outputFile, "output", outputFile, "dump mosek header parsed into a json")
 
+	fromJson := ""
+	flag.StringVar(&fromJson, "from-json", fromJson, "read the header from a json dumped by -output instead of parsing mosek.h")
+
 	outputDir := ""
 	flag.StringVar(&outputDir, "gmsk-dir", outputDir, "gmsk package dir to output the code file to")
 
-	flag.Parse()
+	dumpModel := ""
+	flag.StringVar(&dumpModel, "dump-model", dumpModel, "dump the normalized API model into a json file, or yaml if the file name ends with .yml or .yaml")
 
-	cfg, err := cc.NewConfig(runtime.GOOS, runtime.GOARCH)
-	orPanic(err)
-	cfg.EvalAllMacros = true
-	cfg.UnsignedEnums = true
+	coverageDir := ""
+	flag.StringVar(&coverageDir, "coverage", coverageDir, "dir to write the coverage report coverage.md and coverage.json into, compared with the coverage.json already there")
 
-	sources := []cc.Source{
-		{Name: "<predefined>", Value: cfg.Predefined},
-		{Name: "<builtin>", Value: cc.Builtin},
-		{Name: fileName},
-	}
+	cgoFlags := false
+	flag.BoolVar(&cgoFlags, "cgo-flags", cgoFlags, "write cgo_flags.go with the #cgo CFLAGS and LDFLAGS of the platforms in the mosek installation into the gmsk dir")
 
-	ast, err := cc.Translate(cfg, sources)
-	orPanic(err)
+	pkgConfigDir := ""
+	flag.StringVar(&pkgConfigDir, "pkg-config", pkgConfigDir, "dir to write mosek.pc of the mosek installation into")
+
+	flag.Parse()
 
-	m := NewMosekH().Build(ast, fileName)
+	var m *mskh.MosekH
+	if fromJson != "" {
+		m = getOrPanic(mskh.ReadJSON(fromJson))
+	} else {
+		m = header.parse()
+	}
 
 	if outputFile != "" {
 		b := getOrPanic(json.MarshalIndent(m, "", "  "))
@@ -85,63 +92,46 @@ func main() {
 		orPanic(os.WriteFile(outputFile, b, 0o644))
 	}
 
-	config := newOutputConfig()
+	config := gen.NewOutputConfig()
 
-	orPanic(normalize(m, config))
-
-	for _, enumName := range m.EnumList {
-		if enumName == "MSKrescode_enum" {
-			continue
-		}
-		enumData, ok := m.Enums[enumName]
-		if !ok {
-			log.Panicf("enum %s is not found in parsed mosek.h", enumName)
-		}
-		ec, found := config.Enums[enumName]
-		if !found {
-			log.Panicf("failed to find confing for enum: %s", enumName)
-		}
-		if ec.Skip {
-			continue
-		}
-		fileName := fmt.Sprintf("%s.go", strings.TrimPrefix(enumName, "MSK"))
-		builderToFile(outputDir, fileName, m, config, func(h *MosekH, config *OutputConfig, out io.Writer) error {
-			return enumFileTmpl.Execute(out, &enumFileInput{
-				enumConfig:  ec,
-				CEnum:       enumData,
-				PkgName:     "gmsk",
-				stripPrefix: "MSK_",
-			})
-		})
+	api := getOrPanic(gen.Normalize(m, config))
 
+	if dumpModel != "" {
+		orPanic(writeModel(dumpModel, api))
 	}
 
-	builderToFile(outputDir, "rescodes.go", m, config, func(mh *MosekH, oc *OutputConfig, w io.Writer) error {
-		rescodeEnum, ok := mh.Enums["MSKrescode_enum"]
-		if !ok {
-			return fmt.Errorf("failed to find MSKrescode_enum from parsed mosek header")
+	if coverageDir != "" {
+		var previous *gen.CoverageReport
+		previousFile := path.Join(coverageDir, "coverage.json")
+		if _, err := os.Stat(previousFile); err == nil {
+			previous = getOrPanic(gen.ReadCoverageReport(previousFile))
 		}
+		orPanic(emit.Run(&emit.DirWriter{Dir: coverageDir}, gen.NewCoverageEmitter(m, config, previous)))
+	}
 
-		rc, found := config.Enums["MSKrescode_enum"]
-		if !found {
-			rc = &enumConfig{
-				CommonId: CommonId{},
+	w := &emit.DirWriter{Dir: outputDir, ModulePath: "github.com/fardream/gmsk/v11"}
+	orPanic(emit.Run(w, gen.NewGmskEmitter(m, config)))
+	if outputDir != "" {
+		// the hook is only required when MakeEnv checks the version by default.
+		if err := gen.CheckMakeEnvHook(outputDir); err != nil {
+			if config.CheckVersionInMakeEnv {
+				orPanic(err)
 			}
+			log.Printf("warning: %v", err)
+		}
+	}
+
+	if cgoFlags || pkgConfigDir != "" {
+		install := header.install()
+		if install == nil {
+			log.Panicf("%s is not in a mosek installation", header.fileName)
+		}
+		if cgoFlags {
+			orPanic(emit.Run(w, gen.NewCgoFlagsEmitter(install, m.Version, config)))
+		}
+		if pkgConfigDir != "" {
+			orPanic(emit.Run(&emit.DirWriter{Dir: pkgConfigDir}, gen.NewPkgConfigEmitter(install, m.Version)))
 		}
-		rc.GoName = "ResCode"
-		return enumFileTmpl.Execute(w, &enumFileInput{
-			enumConfig:  rc,
-			CEnum:       rescodeEnum,
-			PkgName:     "gmsk",
-			stripPrefix: "MSK_",
-		})
-	})
-
-	for i := 0; i < int(funcType_LAST); i++ {
-		t := funcType(i)
-		builderToFile(outputDir, t.OutputFile(), m, config, func(mh *MosekH, oc *OutputConfig, w io.Writer) error {
-			return BuildFuncs(mh, oc, t, w)
-		})
 	}
 
 	log.Printf("number of functions: %d", len(m.Functions))
@@ -0,0 +1,84 @@
+// Package model is the normalized API of mosek: the functions with the directions, go names and types of their parameters,
+// and the enums with their constants, after config.yml is applied to mosek.h.
+//
+// Third party emitters are usually created with the model, see [github.com/fardream/gen-gmsk/emit].
+// Fields are only added to the model, existing fields keep their names and meanings.
+package model
+
+// Direction is the direction of a parameter.
+type Direction string
+
+const (
+	In  Direction = "in"  // passed to mosek
+	Out Direction = "out" // returned from the go function
+)
+
+// ParamKind is how a parameter is passed between go and C.
+type ParamKind string
+
+const (
+	Scalar      ParamKind = "scalar"       // passed by value, or by pointer for outputs
+	Bool        ParamKind = "bool"         // MSKbooleant, bool in go
+	Slice       ParamKind = "slice"        // pointer to the first element of a go slice
+	String      ParamKind = "string"       // const char * inputs and char * outputs, string in go
+	Bytes       ParamKind = "bytes"        // char * inputs, *byte in go
+	StringArray ParamKind = "string_array" // const char **, []string in go
+	AllocString ParamKind = "alloc_string" // MSKstring_t * output allocated by mosek
+	AllocSlice  ParamKind = "alloc_slice"  // T ** output allocated by mosek, copied to a go slice
+)
+
+// Param is a parameter of a function, the task or env receiver is not included.
+type Param struct {
+	CName     string    `json:"c_name"`
+	CType     string    `json:"c_type"` // type in mosek.h
+	GoName    string    `json:"go_name"`
+	GoType    string    `json:"go_type"` // element type for slices
+	Direction Direction `json:"direction"`
+	Kind      ParamKind `json:"kind"`
+	Nullable  bool      `json:"nullable,omitempty"`   // nil slice is passed as NULL
+	Length    string    `json:"length,omitempty"`     // go expression of the length of output slices
+	FreesWith string    `json:"frees_with,omitempty"` // C function freeing the memory allocated by mosek
+	SizeFunc  string    `json:"size_func,omitempty"`  // C function returning the size of the buffer passed in this parameter, which is not a go parameter then
+}
+
+// Function is a C function of mosek wrapped in go.
+type Function struct {
+	CName      string   `json:"c_name"`
+	GoName     string   `json:"go_name"`
+	Receiver   string   `json:"receiver,omitempty"` // Task or Env, empty for package level functions
+	FuncType   string   `json:"func_type"`          // category of the function, which is also the output file without .go
+	Doc        string   `json:"doc,omitempty"`
+	URL        string   `json:"url"`
+	Deprecated bool     `json:"deprecated,omitempty"`
+	ReturnType string   `json:"return_type"` // go type of the C return value, ResCode is returned as error
+	Params     []*Param `json:"params"`
+	Source     string   `json:"source,omitempty"`    // file:line of the declaration in mosek.h
+	Prototype  string   `json:"prototype,omitempty"` // declaration in mosek.h in one line
+}
+
+// Constant is a constant of an enum.
+type Constant struct {
+	CName   string `json:"c_name"`
+	GoName  string `json:"go_name"`
+	Value   string `json:"value"`
+	Comment string `json:"comment,omitempty"`
+	Source  string `json:"source,omitempty"` // file:line of the declaration in mosek.h
+}
+
+// Enum is a C enum of mosek.
+type Enum struct {
+	CName       string      `json:"c_name"`
+	GoName      string      `json:"go_name"`
+	IntegerType string      `json:"integer_type"` // underlying go type
+	IsAlias     bool        `json:"is_alias,omitempty"`
+	Doc         string      `json:"doc,omitempty"`
+	Constants   []*Constant `json:"constants"`
+	Source      string      `json:"source,omitempty"` // file:line of the declaration in mosek.h
+}
+
+// API is the normalized API of mosek. Functions and enums skipped by the config are not included.
+type API struct {
+	PackageName string      `json:"package_name"`
+	Functions   []*Function `json:"functions"`
+	Enums       []*Enum     `json:"enums"`
+}
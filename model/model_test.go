@@ -0,0 +1,55 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestJSON pins the json keys of the model, which are read by third party emitters from the dump of -dump-model.
+func TestJSON(t *testing.T) {
+	api := &API{
+		PackageName: "gmsk",
+		Functions: []*Function{{
+			CName:      "MSK_getvarname",
+			GoName:     "GetVarName",
+			Receiver:   "Task",
+			FuncType:   "task_name",
+			URL:        "https://docs.mosek.com",
+			ReturnType: "ResCode",
+			Params: []*Param{
+				{CName: "j", CType: "MSKint32t", GoName: "j", GoType: "int32", Direction: In, Kind: Scalar},
+				{CName: "sizename", CType: "MSKint32t", GoName: "sizename", GoType: "int32", Direction: In, Kind: Scalar, SizeFunc: "MSK_getvarnamelen"},
+				{CName: "name", CType: "char *", GoName: "name", GoType: "string", Direction: Out, Kind: String},
+			},
+			Source: "mosek.h:10",
+		}},
+		Enums: []*Enum{{
+			CName:       "MSKonoffkey_enum",
+			GoName:      "OnOff",
+			IntegerType: "int32",
+			Constants:   []*Constant{{CName: "MSK_ON", GoName: "ON", Value: "1"}},
+		}},
+	}
+	expected := `{"package_name":"gmsk",` +
+		`"functions":[{"c_name":"MSK_getvarname","go_name":"GetVarName","receiver":"Task","func_type":"task_name","url":"https://docs.mosek.com","return_type":"ResCode",` +
+		`"params":[{"c_name":"j","c_type":"MSKint32t","go_name":"j","go_type":"int32","direction":"in","kind":"scalar"},` +
+		`{"c_name":"sizename","c_type":"MSKint32t","go_name":"sizename","go_type":"int32","direction":"in","kind":"scalar","size_func":"MSK_getvarnamelen"},` +
+		`{"c_name":"name","c_type":"char *","go_name":"name","go_type":"string","direction":"out","kind":"string"}],"source":"mosek.h:10"}],` +
+		`"enums":[{"c_name":"MSKonoffkey_enum","go_name":"OnOff","integer_type":"int32","constants":[{"c_name":"MSK_ON","go_name":"ON","value":"1"}]}]}`
+
+	b, err := json.Marshal(api)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != expected {
+		t.Errorf("json is\n%s\nexpected\n%s", b, expected)
+	}
+
+	var decoded API
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := json.Marshal(&decoded); string(again) != expected {
+		t.Errorf("json changes after a round trip\n%s", again)
+	}
+}
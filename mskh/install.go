@@ -0,0 +1,187 @@
+package mskh
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Install is a mosek installation, laid out as Dir/tools/platform/<platform>/h/mosek.h.
+type Install struct {
+	Dir      string // version directory, for example ~/mosek/11.2
+	Version  string // name of the version directory
+	Platform string // platform directory with mosek.h, for example linux64x86
+}
+
+// HeaderPath is the path to mosek.h of the platform.
+func (i *Install) HeaderPath() string {
+	return filepath.Join(i.PlatformDir(i.Platform), "h", "mosek.h")
+}
+
+// PlatformDir is the directory of a platform under tools/platform.
+func (i *Install) PlatformDir(platform string) string {
+	return filepath.Join(i.Dir, "tools", "platform", platform)
+}
+
+// Platforms lists the platform directories with mosek.h, sorted by name.
+func (i *Install) Platforms() []string {
+	entries, err := os.ReadDir(filepath.Join(i.Dir, "tools", "platform"))
+	if err != nil {
+		return nil
+	}
+	var platforms []string
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(i.PlatformDir(e.Name()), "h", "mosek.h")); err == nil {
+			platforms = append(platforms, e.Name())
+		}
+	}
+
+	return platforms
+}
+
+// platformDirs maps GOOS/GOARCH to the platform directory of mosek.
+var platformDirs = map[string]string{
+	"linux/amd64":   "linux64x86",
+	"linux/arm64":   "linuxaarch64",
+	"darwin/amd64":  "osx64x86",
+	"darwin/arm64":  "osxaarch64",
+	"windows/amd64": "win64x86",
+}
+
+// PlatformGoTarget is the GOOS,GOARCH build constraint of a platform directory, empty if unknown.
+func PlatformGoTarget(platform string) string {
+	for target, dir := range platformDirs {
+		if dir == platform {
+			return strings.ReplaceAll(target, "/", ",")
+		}
+	}
+
+	return ""
+}
+
+// InstallRoots are the directories searched for mosek installations: $MSKHOME, $MOSEK_HOME, ~/mosek and /opt/mosek.
+// Each of them contains version directories, or is a version directory itself.
+func InstallRoots() []string {
+	var roots []string
+	for _, env := range []string{"MSKHOME", "MOSEK_HOME"} {
+		if dir := os.Getenv(env); dir != "" {
+			roots = append(roots, dir)
+		}
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		roots = append(roots, filepath.Join(home, "mosek"))
+	}
+
+	return append(roots, "/opt/mosek")
+}
+
+// compareVersions compares dotted versions by their numbers, 11.10 is newer than 11.2.
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < min(len(as), len(bs)); i++ {
+		an, aerr := strconv.Atoi(as[i])
+		bn, berr := strconv.Atoi(bs[i])
+		switch {
+		case aerr == nil && berr == nil && an != bn:
+			return an - bn
+		case aerr != nil || berr != nil:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return len(as) - len(bs)
+}
+
+// newInstall returns the installation in the version directory, or nil if there is no mosek.h in it.
+// The platform of the running go is preferred.
+func newInstall(dir string) *Install {
+	i := &Install{Dir: dir, Version: filepath.Base(dir)}
+	platforms := i.Platforms()
+	if len(platforms) == 0 {
+		return nil
+	}
+	i.Platform = platforms[0]
+	if preferred := platformDirs[runtime.GOOS+"/"+runtime.GOARCH]; slices.Contains(platforms, preferred) {
+		i.Platform = preferred
+	}
+
+	return i
+}
+
+// FindInstalls lists the installations under the roots, the roots are searched in order, and versions in each root from the newest.
+func FindInstalls(roots []string) []*Install {
+	var installs []*Install
+	for _, root := range roots {
+		if i := newInstall(root); i != nil {
+			installs = append(installs, i)
+			continue
+		}
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			continue
+		}
+		var found []*Install
+		for _, e := range entries {
+			if !e.IsDir() {
+				continue
+			}
+			if i := newInstall(filepath.Join(root, e.Name())); i != nil {
+				found = append(found, i)
+			}
+		}
+		slices.SortStableFunc(found, func(a, b *Install) int {
+			return compareVersions(b.Version, a.Version)
+		})
+		installs = append(installs, found...)
+	}
+
+	return installs
+}
+
+// FindInstall finds the newest installation under [InstallRoots], or the one of version if it is not empty.
+func FindInstall(version string) (*Install, error) {
+	roots := InstallRoots()
+	installs := FindInstalls(roots)
+	if version != "" {
+		installs = slices.DeleteFunc(installs, func(i *Install) bool {
+			return i.Version != version
+		})
+	}
+	if len(installs) == 0 {
+		if version != "" {
+			return nil, fmt.Errorf("cannot find mosek %s in %s", version, strings.Join(roots, ", "))
+		}
+		return nil, fmt.Errorf("cannot find mosek in %s", strings.Join(roots, ", "))
+	}
+
+	newest := installs[0]
+	for _, i := range installs[1:] {
+		if compareVersions(i.Version, newest.Version) > 0 {
+			newest = i
+		}
+	}
+
+	return newest, nil
+}
+
+// InstallOfHeader is the installation of mosek.h at Dir/tools/platform/<platform>/h/mosek.h, nil if the header is not in an installation.
+func InstallOfHeader(headerPath string) *Install {
+	hDir := filepath.Dir(headerPath)
+	platformDir := filepath.Dir(hDir)
+	toolsDir := filepath.Dir(filepath.Dir(platformDir))
+	if filepath.Base(hDir) != "h" || filepath.Base(filepath.Dir(platformDir)) != "platform" || filepath.Base(toolsDir) != "tools" {
+		return nil
+	}
+	dir := filepath.Dir(toolsDir)
+
+	return &Install{Dir: dir, Version: filepath.Base(dir), Platform: filepath.Base(platformDir)}
+}
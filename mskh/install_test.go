@@ -0,0 +1,89 @@
+package mskh
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// makeInstall creates dir/version/tools/platform/<platform>/h/mosek.h for the platforms.
+func makeInstall(t *testing.T, dir, version string, platforms ...string) string {
+	t.Helper()
+	versionDir := filepath.Join(dir, version)
+	for _, p := range platforms {
+		hDir := filepath.Join(versionDir, "tools", "platform", p, "h")
+		if err := os.MkdirAll(hDir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(hDir, "mosek.h"), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return versionDir
+}
+
+func TestCompareVersions(t *testing.T) {
+	for _, c := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"11.10", "11.2", 1},
+		{"11.2", "11.2", 0},
+		{"10.2", "11.0", -1},
+		{"11", "11.0", -1},
+		{"11.beta", "11.alpha", 1},
+	} {
+		if got := compareVersions(c.a, c.b); (got > 0) != (c.expected > 0) || (got < 0) != (c.expected < 0) {
+			t.Errorf("compareVersions(%s, %s) = %d, expected sign of %d", c.a, c.b, got, c.expected)
+		}
+	}
+}
+
+func TestFindInstalls(t *testing.T) {
+	root := t.TempDir()
+	makeInstall(t, root, "10.2", "linux64x86")
+	makeInstall(t, root, "11.10", "linux64x86", "osx64x86")
+	makeInstall(t, root, "11.2", "linux64x86")
+	if err := os.MkdirAll(filepath.Join(root, "empty"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	single := makeInstall(t, t.TempDir(), "9.3", "win64x86")
+
+	installs := FindInstalls([]string{root, single, filepath.Join(root, "missing")})
+	var versions []string
+	for _, i := range installs {
+		versions = append(versions, i.Version)
+	}
+	if expected := []string{"11.10", "11.2", "10.2", "9.3"}; !slices.Equal(versions, expected) {
+		t.Errorf("found versions %v, expected %v", versions, expected)
+	}
+	if p := installs[0].Platforms(); !slices.Equal(p, []string{"linux64x86", "osx64x86"}) {
+		t.Errorf("platforms of 11.10 are %v", p)
+	}
+	if installs[3].Platform != "win64x86" {
+		t.Errorf("platform of 9.3 is %s", installs[3].Platform)
+	}
+}
+
+func TestInstallOfHeader(t *testing.T) {
+	i := InstallOfHeader(filepath.Join("/opt", "mosek", "11.2", "tools", "platform", "linux64x86", "h", "mosek.h"))
+	if i == nil || i.Dir != filepath.Join("/opt", "mosek", "11.2") || i.Version != "11.2" || i.Platform != "linux64x86" {
+		t.Fatalf("unexpected install %+v", i)
+	}
+	if i.HeaderPath() != filepath.Join("/opt", "mosek", "11.2", "tools", "platform", "linux64x86", "h", "mosek.h") {
+		t.Errorf("unexpected header path %s", i.HeaderPath())
+	}
+	if i := InstallOfHeader(filepath.Join("testdata", "mosek.h")); i != nil {
+		t.Errorf("header outside of an installation is in %+v", i)
+	}
+}
+
+func TestPlatformGoTarget(t *testing.T) {
+	if got := PlatformGoTarget("osxaarch64"); got != "darwin,arm64" {
+		t.Errorf("PlatformGoTarget(osxaarch64) = %s", got)
+	}
+	if got := PlatformGoTarget("unknown"); got != "" {
+		t.Errorf("PlatformGoTarget(unknown) = %s", got)
+	}
+}
@@ -1,8 +1,12 @@
-package main
+// Package mskh parses mosek.h into the enums, typedefs and functions declared by it.
+package mskh
 
 import (
 	"cmp"
+	"encoding/json"
 	"fmt"
+	"os"
+	"runtime"
 	"slices"
 	"strings"
 
@@ -12,12 +16,14 @@ import (
 type MskEnumValue struct {
 	Name  string `json:"name"`
 	Value string `json:"value"`
+	Source
 }
 
 type MskEnum struct {
 	Name        string         `json:"name"`
 	IntegerType string         `json:"integer_type"`
 	Values      []MskEnumValue `json:"values"`
+	Source
 }
 
 func (e *MskEnum) AddValue(name, value string) *MskEnum {
@@ -38,19 +44,40 @@ type MskFunction struct {
 	Name       string      `json:"name"`
 	Parameters []ParamDecl `json:"parameters"`
 	ReturnType string      `json:"return_type"`
+	IsVariadic bool        `json:"is_variadic"`
+	Prototype  string      `json:"prototype,omitempty"` // declaration in mosek.h in one line
+	Source
 }
 
+// MskVersion is the version of mosek from the MSK_VERSION_* macros.
+type MskVersion struct {
+	Major    int `json:"major"`
+	Minor    int `json:"minor"`
+	Revision int `json:"revision"`
+}
+
+// String is the version as major.minor.revision.
+func (v MskVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Revision)
+}
+
+// SchemaVersion is the version of the json of [MosekH], bumped when fields are changed or added.
+const SchemaVersion = 3
+
 type MosekH struct {
-	Enums     map[string]*MskEnum `json:"enums"`
-	EnumList  []string            `json:"enum_list"`
-	Functions []*MskFunction      `json:"functions"`
-	Typedefs  map[string]string   `json:"typedefs"`
+	SchemaVersion int                 `json:"schema_version"`
+	Version       MskVersion          `json:"version"`
+	Enums         map[string]*MskEnum `json:"enums"`
+	EnumList      []string            `json:"enum_list"`
+	Functions     []*MskFunction      `json:"functions"`
+	Typedefs      map[string]string   `json:"typedefs"`
 }
 
 func NewMosekH() *MosekH {
 	return &MosekH{
-		Enums:    make(map[string]*MskEnum),
-		Typedefs: make(map[string]string),
+		SchemaVersion: SchemaVersion,
+		Enums:         make(map[string]*MskEnum),
+		Typedefs:      make(map[string]string),
 	}
 }
 
@@ -77,6 +104,11 @@ func (h *MosekH) AddEnum(name string, integerType string) *MskEnum {
 	return e
 }
 
+// HasFunction checks if the function is declared in the header.
+func (h *MosekH) HasFunction(name string) bool {
+	return slices.ContainsFunc(h.Functions, func(f *MskFunction) bool { return f.Name == name })
+}
+
 func (h *MosekH) AddEnumTypeDef(orig, defto string) {
 	if h.Typedefs == nil {
 		h.Typedefs = make(map[string]string)
@@ -172,8 +204,17 @@ func cSpelling(t cc.Type, ignoreTypedef bool) string {
 	}
 }
 
-// Build MosekH from the AST
+// Build MosekH from the AST. fileName is read again for the prototypes of the functions.
 func (h *MosekH) Build(ast *cc.AST, fileName string) *MosekH {
+	content, _ := os.ReadFile(fileName)
+	idx := newSourceIndex(ast, fileName, content)
+
+	h.Version = MskVersion{
+		Major:    macroInt(ast, "MSK_VERSION_MAJOR"),
+		Minor:    macroInt(ast, "MSK_VERSION_MINOR"),
+		Revision: macroInt(ast, "MSK_VERSION_REVISION"),
+	}
+
 	var enums []*cc.EnumSpecifier
 	var functions []*cc.Declarator
 	var typedefs []*cc.Declarator
@@ -219,7 +260,10 @@ func (h *MosekH) Build(ast *cc.AST, fileName string) *MosekH {
 		}
 		integerType := cSpelling(et.UnderlyingType(), false)
 		me := h.AddEnum(tag, integerType)
-		for _, ev := range et.Enumerators() {
+		me.Source = idx.declSource(e)
+		enumerators := et.Enumerators()
+		sources := idx.enumeratorSources(e, enumerators)
+		for k, ev := range enumerators {
 			var valStr string
 			switch v := ev.Value().(type) {
 			case cc.Int64Value:
@@ -230,6 +274,7 @@ func (h *MosekH) Build(ast *cc.AST, fileName string) *MosekH {
 				valStr = fmt.Sprintf("%v", ev.Value())
 			}
 			me.AddValue(ev.Token.SrcStr(), valStr)
+			me.Values[len(me.Values)-1].Source = sources[k]
 		}
 	}
 
@@ -250,6 +295,9 @@ func (h *MosekH) Build(ast *cc.AST, fileName string) *MosekH {
 		mf := &MskFunction{
 			Name:       f.Name(),
 			ReturnType: cSpelling(ft.Result(), false),
+			IsVariadic: ft.IsVariadic(),
+			Prototype:  idx.prototype(f),
+			Source:     idx.declSource(f),
 		}
 		for _, param := range ft.Parameters() {
 			if param.Type().Kind() == cc.Void {
@@ -265,3 +313,93 @@ func (h *MosekH) Build(ast *cc.AST, fileName string) *MosekH {
 
 	return h
 }
+
+// ParseOptions are the extra include paths and macros for parsing mosek.h.
+type ParseOptions struct {
+	IncludePaths []string // searched by both #include "" and #include <>
+	Defines      []string // NAME or NAME=VALUE, like -D of the c compiler
+}
+
+// predefined is the source defining the macros.
+func (o *ParseOptions) predefined() string {
+	var b strings.Builder
+	for _, d := range o.Defines {
+		name, value, found := strings.Cut(d, "=")
+		if !found {
+			value = "1"
+		}
+		fmt.Fprintf(&b, "#define %s %s\n", name, value)
+	}
+
+	return b.String()
+}
+
+// macroInt is the value of an integer macro, 0 if it is not defined.
+func macroInt(ast *cc.AST, name string) int {
+	m, found := ast.Macros[name]
+	if !found {
+		return 0
+	}
+	switch v := m.Value().(type) {
+	case cc.Int64Value:
+		return int(v)
+	case cc.UInt64Value:
+		return int(v)
+	default:
+		return 0
+	}
+}
+
+// Parse parses mosek.h into [MosekH], opts can be nil.
+func Parse(fileName string, opts *ParseOptions) (*MosekH, error) {
+	cfg, err := cc.NewConfig(runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return nil, err
+	}
+	cfg.EvalAllMacros = true
+	cfg.UnsignedEnums = true
+	if opts == nil {
+		opts = &ParseOptions{}
+	}
+	cfg.IncludePaths = append(cfg.IncludePaths, opts.IncludePaths...)
+	cfg.SysIncludePaths = append(cfg.SysIncludePaths, opts.IncludePaths...)
+
+	sources := []cc.Source{
+		// cfg.Predefined does not end with a new line.
+		{Name: "<predefined>", Value: cfg.Predefined + "\n" + opts.predefined()},
+		{Name: "<builtin>", Value: cc.Builtin},
+		{Name: fileName},
+	}
+
+	ast, err := cc.Translate(cfg, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMosekH().Build(ast, fileName), nil
+}
+
+// ReadJSON reads [MosekH] dumped as json, for example by the -output flag of the generator.
+// The schema version of the dump must be [SchemaVersion].
+func ReadJSON(fileName string) (*MosekH, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &MosekH{}
+	if err := json.Unmarshal(content, h); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", fileName, err)
+	}
+	if h.SchemaVersion != SchemaVersion {
+		return nil, fmt.Errorf("schema version of %s is %d, but %d is expected, dump mosek.h again with this generator", fileName, h.SchemaVersion, SchemaVersion)
+	}
+	if h.Enums == nil {
+		h.Enums = make(map[string]*MskEnum)
+	}
+	if h.Typedefs == nil {
+		h.Typedefs = make(map[string]string)
+	}
+
+	return h, nil
+}
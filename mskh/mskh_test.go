@@ -0,0 +1,83 @@
+package mskh
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func parseTestHeader(t *testing.T, opts *ParseOptions) *MosekH {
+	t.Helper()
+	h, err := Parse(filepath.Join("testdata", "mosek.h"), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func findFunction(h *MosekH, name string) *MskFunction {
+	i := slices.IndexFunc(h.Functions, func(f *MskFunction) bool { return f.Name == name })
+	if i < 0 {
+		return nil
+	}
+	return h.Functions[i]
+}
+
+func TestParse(t *testing.T) {
+	h := parseTestHeader(t, nil)
+
+	if v := h.Version.String(); v != "11.2.3" {
+		t.Errorf("version is %s, expected 11.2.3", v)
+	}
+	if !slices.Equal(h.EnumList, []string{"MSKrescode_enum", "MSKonoffkey_enum"}) {
+		t.Errorf("unexpected enums %v", h.EnumList)
+	}
+	if h.Typedefs["MSKrescodee"] != "enum MSKrescode_enum" {
+		t.Errorf("MSKrescodee is typedef of %q", h.Typedefs["MSKrescodee"])
+	}
+
+	rescode := h.Enums["MSKrescode_enum"]
+	if rescode == nil || len(rescode.Values) != 2 {
+		t.Fatalf("unexpected MSKrescode_enum %+v", rescode)
+	}
+	if rescode.Source.String() != "mosek.h:11" || !slices.Equal(rescode.Comments, []string{"Response codes"}) {
+		t.Errorf("unexpected source of MSKrescode_enum %+v", rescode.Source)
+	}
+	if v := rescode.Values[0]; v.Name != "MSK_RES_OK" || v.Value != "0" || v.Line != 12 || !slices.Equal(v.Comments, []string{"No error occurred."}) {
+		t.Errorf("unexpected MSK_RES_OK %+v", v)
+	}
+	if v := rescode.Values[1]; v.Name != "MSK_RES_ERR_LICENSE" || v.Value != "1000" {
+		t.Errorf("unexpected MSK_RES_ERR_LICENSE %+v", v)
+	}
+
+	f := findFunction(h, "MSK_getnumvar")
+	if f == nil {
+		t.Fatal("MSK_getnumvar is not found")
+	}
+	if f.ReturnType != "MSKrescodee" || f.IsVariadic || !slices.Equal(f.Parameters, []ParamDecl{{"task", "MSKtask_t"}, {"numvar", "MSKint32t *"}}) {
+		t.Errorf("unexpected MSK_getnumvar %+v", f)
+	}
+	if f.Source.String() != "mosek.h:33" || !slices.Equal(f.Comments, []string{"Obtains the number of variables."}) {
+		t.Errorf("unexpected source of MSK_getnumvar %+v", f.Source)
+	}
+	if expected := "MSKrescodee (MSKAPI MSK_getnumvar) ( MSKtask_t task, MSKint32t * numvar);"; f.Prototype != expected {
+		t.Errorf("prototype is %q, expected %q", f.Prototype, expected)
+	}
+	if f.Ref() != "MSK_getnumvar (mosek.h:33)" {
+		t.Errorf("unexpected ref %s", f.Ref())
+	}
+
+	if f := findFunction(h, "MSK_echotask"); f == nil || !f.IsVariadic {
+		t.Errorf("MSK_echotask is not variadic: %+v", f)
+	}
+	if findFunction(h, "MSK_extra") != nil {
+		t.Error("MSK_extra is declared without MSK_TEST_EXTRA")
+	}
+}
+
+func TestParseDefines(t *testing.T) {
+	h := parseTestHeader(t, &ParseOptions{Defines: []string{"MSK_TEST_EXTRA"}})
+	if findFunction(h, "MSK_extra") == nil {
+		t.Error("MSK_extra is not declared with MSK_TEST_EXTRA")
+	}
+}
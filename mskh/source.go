@@ -0,0 +1,185 @@
+package mskh
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"modernc.org/cc/v4"
+)
+
+// Source is where a declaration is in the header, and the comments next to it.
+type Source struct {
+	File     string   `json:"file,omitempty"` // base name of the header
+	Line     int      `json:"line,omitempty"`
+	Comments []string `json:"comments,omitempty"` // without the comment markers
+}
+
+// String is file:line, empty if the position is unknown, for example for headers from the rust binding.
+func (s Source) String() string {
+	if s.Line == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", s.File, s.Line)
+}
+
+// ref is the name with the position of the declaration if it is known, for diagnostics.
+func (s Source) ref(name string) string {
+	if s.Line == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, s)
+}
+
+// Ref is the name of the function with the position of its declaration, for diagnostics.
+func (f *MskFunction) Ref() string {
+	return f.Source.ref(f.Name)
+}
+
+// Ref is the name of the enum with the position of its declaration, for diagnostics.
+func (e *MskEnum) Ref() string {
+	return e.Source.ref(e.Name)
+}
+
+// Ref is the name of the value with the position of its declaration, for diagnostics.
+func (v *MskEnumValue) Ref() string {
+	return v.Source.ref(v.Name)
+}
+
+var (
+	commentRegex    = regexp.MustCompile(`(?s)/\*.*?\*/|//[^\n]*`)
+	blankLineRegex  = regexp.MustCompile(`\n[ \t]*\n`)
+	whitespaceRegex = regexp.MustCompile(`\s+`)
+)
+
+// parseComments returns the comments in the text, without the markers.
+func parseComments(text string) []string {
+	var r []string
+	for _, c := range commentRegex.FindAllString(text, -1) {
+		if strings.HasPrefix(c, "//") {
+			c = strings.TrimPrefix(c, "//")
+		} else {
+			c = strings.TrimSuffix(strings.TrimPrefix(c, "/*"), "*/")
+		}
+		if c = strings.TrimSpace(c); c != "" {
+			r = append(r, c)
+		}
+	}
+
+	return r
+}
+
+// splitSep splits the separator before a token into the comments trailing the previous line,
+// and the comments leading the token, which are not separated from it by a blank line.
+func splitSep(sep []byte) (trailing, leading []string) {
+	s := string(sep)
+	before, after, found := strings.Cut(s, "\n")
+	if !found {
+		return parseComments(s), nil
+	}
+	if loc := blankLineRegex.FindAllStringIndex("\n"+after, -1); len(loc) > 0 {
+		after = ("\n" + after)[loc[len(loc)-1][1]:]
+	}
+
+	return parseComments(before), parseComments(after)
+}
+
+// declaration is the tokens of an external declaration in the header.
+type declaration struct {
+	offset int
+	tokens []cc.Token
+}
+
+// sourceIndex finds the declarations and comments of nodes in the header.
+type sourceIndex struct {
+	file         string
+	content      []byte
+	declarations []*declaration
+}
+
+func newSourceIndex(ast *cc.AST, fileName string, content []byte) *sourceIndex {
+	idx := &sourceIndex{file: filepath.Base(fileName), content: content}
+	for l := ast.TranslationUnit; l != nil; l = l.TranslationUnit {
+		ed := l.ExternalDeclaration
+		if ed == nil || ed.Position().Filename != fileName {
+			continue
+		}
+		tokens := cc.NodeTokens(ed)
+		if len(tokens) == 0 {
+			continue
+		}
+		idx.declarations = append(idx.declarations, &declaration{offset: tokens[0].Position().Offset, tokens: tokens})
+	}
+	slices.SortFunc(idx.declarations, func(a, b *declaration) int {
+		return cmp.Compare(a.offset, b.offset)
+	})
+
+	return idx
+}
+
+// declarationOf is the external declaration containing the offset, nil if there is none.
+func (idx *sourceIndex) declarationOf(offset int) *declaration {
+	i, _ := slices.BinarySearchFunc(idx.declarations, offset, func(d *declaration, offset int) int {
+		return cmp.Compare(d.offset, offset)
+	})
+	if i < len(idx.declarations) && idx.declarations[i].offset == offset {
+		return idx.declarations[i]
+	}
+	if i == 0 {
+		return nil
+	}
+
+	return idx.declarations[i-1]
+}
+
+// declSource is the position of the node, with the comments before its declaration.
+func (idx *sourceIndex) declSource(n cc.Node) Source {
+	pos := n.Position()
+	s := Source{File: idx.file, Line: pos.Line}
+	if d := idx.declarationOf(pos.Offset); d != nil {
+		_, s.Comments = splitSep(d.tokens[0].Sep())
+	}
+
+	return s
+}
+
+// prototype is the source of the declaration of the node in one line.
+func (idx *sourceIndex) prototype(n cc.Node) string {
+	d := idx.declarationOf(n.Position().Offset)
+	if d == nil || idx.content == nil {
+		return ""
+	}
+	last := d.tokens[len(d.tokens)-1]
+	start, end := d.offset, last.Position().Offset+len(last.Src())
+	if start < 0 || end > len(idx.content) || start >= end {
+		return ""
+	}
+
+	return whitespaceRegex.ReplaceAllString(string(idx.content[start:end]), " ")
+}
+
+// enumeratorSources are the positions of the enumerators, with the comments before them and the comments after them on the same line.
+func (idx *sourceIndex) enumeratorSources(e *cc.EnumSpecifier, enumerators []*cc.Enumerator) []Source {
+	tokens := cc.NodeTokens(e)
+	r := make([]Source, len(enumerators))
+	for k, ev := range enumerators {
+		r[k] = Source{File: idx.file, Line: ev.Token.Position().Line}
+		i := slices.IndexFunc(tokens, func(t cc.Token) bool { return t.Seq() == ev.Token.Seq() })
+		if i < 0 {
+			continue
+		}
+		_, r[k].Comments = splitSep(tokens[i].Sep())
+		for _, t := range tokens[i+1:] {
+			trailing, _ := splitSep(t.Sep())
+			r[k].Comments = append(r[k].Comments, trailing...)
+			if strings.Contains(string(t.Sep()), "\n") {
+				break
+			}
+		}
+	}
+
+	return r
+}
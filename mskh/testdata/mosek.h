@@ -0,0 +1,38 @@
+#ifndef MOSEK_H
+#define MOSEK_H
+
+#define MSK_VERSION_MAJOR    11
+#define MSK_VERSION_MINOR    2
+#define MSK_VERSION_REVISION 3
+
+#define MSKAPI
+
+/* Response codes */
+enum MSKrescode_enum {
+  MSK_RES_OK = 0, /* No error occurred. */
+  MSK_RES_ERR_LICENSE = 1000
+};
+
+enum MSKonoffkey_enum {
+  MSK_OFF = 0,
+  MSK_ON = 1
+};
+
+typedef int MSKint32t;
+typedef double MSKrealt;
+typedef void * MSKtask_t;
+
+typedef enum MSKrescode_enum MSKrescodee;
+typedef enum MSKonoffkey_enum MSKonoffkeye;
+
+#ifdef MSK_TEST_EXTRA
+MSKrescodee (MSKAPI MSK_extra) (MSKtask_t task);
+#endif
+
+/* Obtains the number of variables. */
+MSKrescodee (MSKAPI MSK_getnumvar) (
+  MSKtask_t task,
+  MSKint32t * numvar);
+MSKrescodee (MSKAPI MSK_echotask) (MSKtask_t task, MSKint32t whichstream, const char * format, ...);
+
+#endif
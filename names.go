@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/fardream/gen-gmsk/gen"
+	"github.com/fardream/gen-gmsk/mskh"
+)
+
+// runNames is the names command, which prints the name table and optionally compares it with a golden file.
+func runNames(args []string) {
+	fs := flag.NewFlagSet("names", flag.ExitOnError)
+	header := addHeaderFlags(fs)
+	rustLib := ""
+	fs.StringVar(&rustLib, "rust-lib", rustLib, "take function names from the extern block of mosek rust binding and the functions in the config instead of mosek.h, for example from-rust/data/mosek-lib.rs")
+	golden := ""
+	fs.StringVar(&golden, "golden", golden, "compare the name table with this golden file")
+	update := false
+	fs.BoolVar(&update, "update", update, "write the name table to the golden file instead of comparing")
+	orPanic(fs.Parse(args))
+
+	config := gen.NewOutputConfig()
+	var m *mskh.MosekH
+	if rustLib != "" {
+		m = getOrPanic(gen.HeaderFromKnownFunctions(rustLib, config))
+	} else {
+		m = header.parse()
+	}
+
+	getOrPanic(gen.Normalize(m, config))
+
+	var table bytes.Buffer
+	orPanic(gen.WriteNameTable(m, config, &table))
+
+	switch {
+	case golden == "":
+		os.Stdout.Write(table.Bytes())
+	case update:
+		orPanic(os.WriteFile(golden, table.Bytes(), 0o644))
+	default:
+		expected := getOrPanic(os.ReadFile(golden))
+		if !bytes.Equal(expected, table.Bytes()) {
+			expectedLines, gotLines := bytes.Split(expected, []byte("\n")), bytes.Split(table.Bytes(), []byte("\n"))
+			for i := 0; i < max(len(expectedLines), len(gotLines)); i++ {
+				var e, g []byte
+				if i < len(expectedLines) {
+					e = expectedLines[i]
+				}
+				if i < len(gotLines) {
+					g = gotLines[i]
+				}
+				if !bytes.Equal(e, g) {
+					fmt.Fprintf(os.Stderr, "line %d:\n-%s\n+%s\n", i+1, e, g)
+				}
+			}
+			log.Panicf("name table differs from %s, rerun with -update if the change is intended", golden)
+		}
+	}
+}
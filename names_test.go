@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fardream/gen-gmsk/gen"
+)
+
+var update = flag.Bool("update", false, "write the name table to testdata/names.golden instead of comparing")
+
+const namesGolden = "testdata/names.golden"
+
+func TestNamesGolden(t *testing.T) {
+	config := gen.NewOutputConfig()
+	h, err := gen.HeaderFromKnownFunctions("gen/from-rust/data/mosek-lib.rs", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gen.Normalize(h, config); err != nil {
+		t.Fatal(err)
+	}
+	var table bytes.Buffer
+	if err := gen.WriteNameTable(h, config, &table); err != nil {
+		t.Fatal(err)
+	}
+
+	if *update {
+		if err := os.WriteFile(namesGolden, table.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(namesGolden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedLines, gotLines := strings.Split(string(expected), "\n"), strings.Split(table.String(), "\n")
+	for i := 0; i < max(len(expectedLines), len(gotLines)); i++ {
+		var e, g string
+		if i < len(expectedLines) {
+			e = expectedLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if e != g {
+			t.Errorf("%s:%d:\n-%s\n+%s", namesGolden, i+1, e, g)
+		}
+	}
+	if t.Failed() {
+		t.Log("run go test -run TestNamesGolden -update if the change is intended")
+	}
+}